@@ -5,15 +5,25 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"log/slog"
 	"os"
+	"os/signal"
+	pathpkg "path"
 	"path/filepath"
 	"regexp"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
+	"octo-linter/internal/advisory"
+	"octo-linter/internal/archive"
 	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/gitcli"
 	"octo-linter/internal/linter"
 	"octo-linter/internal/loglevel"
+	"octo-linter/internal/profile"
 )
 
 //go:generate go run ../../gen.go ../../
@@ -32,9 +42,15 @@ const (
 	ExitErrReadingDefaultCfgFile = 32
 	ExitErrReadingVarsFile       = 41
 	ExitErrReadingSecretsFile    = 42
+	ExitErrReadingStdin          = 43
+	ExitErrGettingChangedFiles   = 44
+	ExitErrReadingGitRef         = 45
+	ExitErrReadingArchive        = 46
 	ExitErrCheckingDstPath       = 50
 	ExitDstFileIsDir             = 51
 	ExitErrWritingCfg            = 52
+	ExitErrImportingAdvisories   = 60
+	ExitErrWritingAdvisories     = 61
 )
 
 const (
@@ -50,6 +66,7 @@ var (
 	errDotGithubDirRead         = errors.New("error reading .github directory")
 	errDotGithubVarsFileRead    = errors.New("error reading vars file")
 	errDotGithubSecretsFileRead = errors.New("error reading secrets file")
+	errDotGithubNotInArchive    = errors.New("archive does not contain a .github directory")
 )
 
 func errGettingCfgFile(err error) error {
@@ -88,6 +105,7 @@ func main() {
 
 	rootCmd.AddCommand(createInitCommand())
 	rootCmd.AddCommand(createLintCommand())
+	rootCmd.AddCommand(createAdvisoriesCommand())
 	rootCmd.AddCommand(&cobra.Command{
 		Use:   "version",
 		Short: "Prints the current version of the tool",
@@ -96,65 +114,169 @@ func main() {
 		},
 	})
 
-	if err := rootCmd.Execute(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
 
+// lintOptions contains values of the 'lint' command flags and arguments.
+type lintOptions struct {
+	logLevel      string
+	logMultiline  bool
+	path          string
+	config        string
+	varsFile      string
+	secretsFile   string
+	output        string
+	outputErrors  int
+	rootAction    string
+	files         []string
+	stdinFilename string
+	changedSince  string
+	gitRef        string
+	archivePath   string
+	cacheDir      string
+	jobs          int
+	timeout       time.Duration
+	ruleTimeout   time.Duration
+	profileReport bool
+	profileTrace  string
+}
+
 func createLintCommand() *cobra.Command {
-	var path, config, loglevel, varsFile, secretsFile, output string
-	var logmultiline bool
-	var outputErrors int
+	var opts lintOptions
+	var stdin bool
 
 	cmd := &cobra.Command{
-		Use:   "lint",
-		Short: "Runs the linter on files from a specific directory",
+		Use:   "lint [file...]",
+		Short: "Runs the linter on files from a specific directory, or only on the given files",
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			if _, err := os.Stat(path); os.IsNotExist(err) {
-				return fmt.Errorf("path '%s' does not exist or is not a directory", path)
+			if stdin && opts.stdinFilename == "" {
+				return errors.New("stdin-filename is required when reading from stdin")
+			}
+			if !stdin && opts.stdinFilename != "" {
+				return errors.New("stdin-filename can only be used with stdin")
+			}
+			if opts.changedSince != "" && (stdin || len(args) > 0) {
+				return errors.New("changed-since cannot be used with files or stdin")
+			}
+			if opts.gitRef != "" && (stdin || len(args) > 0 || opts.changedSince != "") {
+				return errors.New("git-ref cannot be used with files, stdin or changed-since")
 			}
-			if config != "" {
-				if _, err := os.Stat(config); os.IsNotExist(err) {
-					return fmt.Errorf("config file '%s' does not exist", config)
+			if opts.gitRef != "" && opts.path == "" {
+				return errors.New("path is required when linting a git ref")
+			}
+			if opts.archivePath != "" && (stdin || len(args) > 0 || opts.changedSince != "" || opts.gitRef != "") {
+				return errors.New("archive cannot be used with files, stdin, changed-since or git-ref")
+			}
+			if opts.archivePath != "" {
+				if err := checkPath("archive", opts.archivePath, false); err != nil {
+					return err
+				}
+			}
+			for _, file := range args {
+				if err := checkPath("file", file, false); err != nil {
+					return err
 				}
 			}
-			if varsFile != "" {
-				if _, err := os.Stat(varsFile); os.IsNotExist(err) {
-					return fmt.Errorf("vars-file '%s' does not exist", varsFile)
+			if opts.path == "" && opts.archivePath == "" {
+				opts.path = getDotGithubPathFromFiles(append(args, opts.stdinFilename))
+				if opts.path == "" {
+					return errors.New("path is required when no file inside a .github directory is given")
 				}
 			}
-			if secretsFile != "" {
-				if _, err := os.Stat(secretsFile); os.IsNotExist(err) {
-					return fmt.Errorf("secrets-file '%s' does not exist", secretsFile)
+			// with git-ref or archive the path is not read from disk so it does not need to exist
+			if _, err := os.Stat(opts.path); os.IsNotExist(err) && opts.gitRef == "" && opts.archivePath == "" {
+				return fmt.Errorf("path '%s' does not exist or is not a directory", opts.path)
+			}
+			if opts.config != "" {
+				if _, err := os.Stat(opts.config); os.IsNotExist(err) {
+					return fmt.Errorf("config file '%s' does not exist", opts.config)
 				}
 			}
-			if output != "" {
-				fileInfo, err := os.Stat(output)
-				if os.IsNotExist(err) || !fileInfo.IsDir() {
-					return fmt.Errorf("output '%s' does not exist or is not a directory", output)
+			if opts.varsFile != "" {
+				if _, err := os.Stat(opts.varsFile); os.IsNotExist(err) {
+					return fmt.Errorf("vars-file '%s' does not exist", opts.varsFile)
+				}
+			}
+			if opts.secretsFile != "" {
+				if _, err := os.Stat(opts.secretsFile); os.IsNotExist(err) {
+					return fmt.Errorf("secrets-file '%s' does not exist", opts.secretsFile)
+				}
+			}
+			if opts.output != "" {
+				if err := checkPath("output", opts.output, true); err != nil {
+					return err
+				}
+			}
+			if opts.jobs < 0 {
+				return errors.New("jobs cannot be negative")
+			}
+			if opts.timeout < 0 || opts.ruleTimeout < 0 {
+				return errors.New("timeout and rule-timeout cannot be negative")
+			}
+			if opts.rootAction != "" && opts.gitRef == "" && opts.archivePath == "" {
+				if err := checkPath("root-action", opts.rootAction, true); err != nil {
+					return err
 				}
 			}
 			return nil
 		},
 		Run: func(cmd *cobra.Command, args []string) {
-			os.Exit(lintHandler(cmd.Context(), loglevel, logmultiline, path, config, varsFile, secretsFile, output, outputErrors))
+			if opts.rootAction == "" && opts.archivePath == "" {
+				opts.rootAction = getRootActionPath(opts.path)
+			}
+			opts.files = args
+			os.Exit(lintHandler(cmd.Context(), opts))
 		},
 	}
 
-	cmd.Flags().StringVarP(&path, "path", "p", "", "Path to .github directory (required)")
-	cmd.MarkFlagRequired("path")
-	cmd.Flags().StringVarP(&config, "config", "c", "", "Linter config with rules in YAML format")
-	cmd.Flags().StringVarP(&loglevel, "loglevel", "l", "", "One of INFO, ERR, WARN, DEBUG")
-	cmd.Flags().BoolVarP(&logmultiline, "logmultiline", "m", false, "Each log entry key in a separate line")
-	cmd.Flags().StringVarP(&varsFile, "vars-file", "z", "", "Check if variable names exist in this file (one per line)")
-	cmd.Flags().StringVarP(&secretsFile, "secrets-file", "s", "", "Check if secret names exist in this file (one per line)")
-	cmd.Flags().StringVarP(&output, "output", "o", "", "Path to where summary markdown gets generated")
-	cmd.Flags().IntVarP(&outputErrors, "output-errors", "u", 0, "Limit numbers of errors shown in the markdown output file")
+	cmd.Flags().StringVarP(&opts.path, "path", "p", "", "Path to .github directory (defaults to the one containing the given files)")
+	cmd.Flags().StringVarP(&opts.config, "config", "c", "", "Linter config with rules in YAML format")
+	cmd.Flags().StringVarP(&opts.logLevel, "loglevel", "l", "", "One of INFO, ERR, WARN, DEBUG")
+	cmd.Flags().BoolVarP(&opts.logMultiline, "logmultiline", "m", false, "Each log entry key in a separate line")
+	cmd.Flags().StringVarP(&opts.varsFile, "vars-file", "z", "", "Check if variable names exist in this file (one per line)")
+	cmd.Flags().StringVarP(&opts.secretsFile, "secrets-file", "s", "", "Check if secret names exist in this file (one per line)")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "Path to where summary markdown gets generated")
+	cmd.Flags().IntVarP(&opts.outputErrors, "output-errors", "u", 0, "Limit numbers of errors shown in the markdown output file")
+	cmd.Flags().StringVar(&opts.rootAction, "root-action", "", "Path to directory with a repository-root action.yml (defaults to parent of --path when it is .github)")
+	cmd.Flags().BoolVar(&stdin, "stdin", false, "Read contents of the file given in --stdin-filename from stdin")
+	cmd.Flags().StringVar(&opts.stdinFilename, "stdin-filename", "", "Path of the file read from stdin, eg. .github/workflows/main.yml")
+	cmd.Flags().StringVar(&opts.gitRef, "git-ref", "", "Lint files as they are in the given git commit, tag or branch instead of the working tree")
+	cmd.Flags().StringVar(&opts.archivePath, "archive", "", "Lint files from a .zip, .tar or .tar.gz archive of a repository, with --path relative to it")
+	cmd.Flags().StringVar(&opts.changedSince, "changed-since", "", "Lint only files changed since the given git ref, and files calling changed local actions")
+	cmd.Flags().IntVarP(&opts.jobs, "jobs", "j", 0, "Number of rules run concurrently (defaults to the number of CPUs)")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 0, "Stop linting with an error after the given time, eg. 2m (no limit by default)")
+	cmd.Flags().DurationVar(&opts.ruleTimeout, "rule-timeout", linter.SecondsJobTimeout*time.Second, "Limit time a single rule can take on a single file")
+	cmd.Flags().BoolVar(&opts.profileReport, "profile", false, "Print time taken by loading files, downloading external actions, and the slowest rules and files")
+	cmd.Flags().StringVar(&opts.profileTrace, "profile-trace", "", "Write time taken by each rule on each file to a Chrome trace event JSON file")
+	cmd.Flags().StringVar(&opts.cacheDir, "cache-dir", "", "Directory to cache rule results in, so that unchanged files are not linted again")
 
 	return cmd
 }
 
+// checkPath checks whether the path given in a flag exists and whether it is a directory or a file, as expected.
+func checkPath(name string, path string, isDir bool) error {
+	fileInfo, err := os.Stat(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error checking %s '%s': %w", name, path, err)
+	}
+
+	if isDir && (err != nil || !fileInfo.IsDir()) {
+		return fmt.Errorf("%s '%s' does not exist or is not a directory", name, path)
+	}
+
+	if !isDir && (err != nil || fileInfo.IsDir()) {
+		return fmt.Errorf("%s '%s' does not exist or is a directory", name, path)
+	}
+
+	return nil
+}
+
 func createInitCommand() *cobra.Command {
 	var destination string
 	cmd := &cobra.Command{
@@ -169,6 +291,39 @@ func createInitCommand() *cobra.Command {
 	return cmd
 }
 
+func createAdvisoriesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "advisories",
+		Short: "Manages the advisory file used by the must_not_have_advisories rules",
+	}
+
+	var destination string
+	importCmd := &cobra.Command{
+		Use:   "import path...",
+		Short: "Converts a GitHub Advisory Database export into an advisory file with actions only",
+		Long: "Converts a GitHub Advisory Database export into an advisory file with actions only. Each path can be a JSON" +
+			" file, in OSV format or a list returned by the GitHub advisories API, or a directory with such files, eg. a" +
+			" clone of the github/advisory-database repository.",
+		Args: cobra.MinimumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			for _, path := range args {
+				if _, err := os.Stat(path); os.IsNotExist(err) {
+					return fmt.Errorf("path '%s' does not exist", path)
+				}
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			os.Exit(advisoriesImportHandler(cmd.Context(), args, destination))
+		},
+	}
+
+	importCmd.Flags().StringVarP(&destination, "destination", "d", "advisories.yml", "Destination filename to write to, in JSON format when it ends with .json")
+	cmd.AddCommand(importCmd)
+
+	return cmd
+}
+
 func versionHandler(_ context.Context) int {
 	_, _ = fmt.Fprintf(os.Stdout, VERSION+"\n")
 
@@ -221,10 +376,79 @@ func initHandler(_ context.Context, path string) int {
 	return ExitOK
 }
 
-func lintHandler(ctx context.Context, loglevel string, logmultiline bool, path, config, varsFile, secretsFile, output string, outputErrors int) int {
-	setLogger(loglevel, logmultiline)
+func advisoriesImportHandler(_ context.Context, paths []string, destination string) int {
+	database, err := advisory.Import(paths)
+	if err != nil {
+		slog.Error(
+			"error importing advisories",
+			slog.String("err", err.Error()),
+		)
+
+		return ExitErrImportingAdvisories
+	}
 
-	lint, err := getLinter(config, path)
+	err = database.Write(destination)
+	if err != nil {
+		slog.Error(
+			"error writing advisories",
+			slog.String("path", destination),
+			slog.String("err", err.Error()),
+		)
+
+		return ExitErrWritingAdvisories
+	}
+
+	slog.Info(
+		"Advisories have been imported. Set the path to the file in the must_not_have_advisories rules.",
+		slog.String("path", destination),
+		slog.Int("advisories", len(database.Advisories)),
+	)
+
+	return ExitOK
+}
+
+func lintHandler(ctx context.Context, opts lintOptions) int {
+	setLogger(opts.logLevel, opts.logMultiline)
+
+	var profiler *profile.Profiler
+	if opts.profileReport || opts.profileTrace != "" {
+		profiler = profile.New()
+		defer writeProfile(profiler, opts.profileReport, opts.profileTrace)
+	}
+
+	overlay := map[string][]byte{}
+	if opts.stdinFilename != "" {
+		stdinPath, err := filepath.Abs(opts.stdinFilename)
+		if err != nil {
+			slog.Error(
+				"error getting absolute path of stdin filename",
+				slog.String("path", opts.stdinFilename),
+				slog.String("err", err.Error()),
+			)
+
+			return ExitErrReadingStdin
+		}
+
+		overlay[stdinPath], err = io.ReadAll(os.Stdin)
+		if err != nil {
+			slog.Error(
+				"error reading stdin",
+				slog.String("err", err.Error()),
+			)
+
+			return ExitErrReadingStdin
+		}
+
+		opts.files = append(opts.files, opts.stdinFilename)
+	}
+
+	// the config file is looked up in .github on disk, which is not there when linting an archive
+	configDir := opts.path
+	if opts.archivePath != "" {
+		configDir = ""
+	}
+
+	lint, err := getLinter(opts.config, configDir)
 	if err != nil && errors.Is(err, errCfgFileGet) {
 		return ExitErrGettingCfgFile
 	}
@@ -237,11 +461,57 @@ func lintHandler(ctx context.Context, loglevel string, logmultiline bool, path,
 		return ExitErrReadingDefaultCfgFile
 	}
 
+	lint.Jobs = opts.jobs
+	lint.Timeout = opts.timeout
+	lint.RuleTimeout = opts.ruleTimeout
+	lint.Profiler = profiler
+
+	if opts.cacheDir != "" {
+		lint.Cache, err = linter.NewCache(opts.cacheDir, VERSION)
+		if err != nil {
+			slog.Warn(
+				"linting without cache",
+				slog.String("err", err.Error()),
+			)
+		}
+	}
+
+	var fsys fs.FS
+	if opts.gitRef != "" {
+		fsys, opts.path, opts.rootAction, err = getGitRefFS(ctx, opts.gitRef, opts.path, opts.rootAction)
+		if err != nil {
+			slog.Error(
+				"error reading git ref",
+				slog.String("ref", opts.gitRef),
+				slog.String("err", err.Error()),
+			)
+
+			return ExitErrReadingGitRef
+		}
+	}
+
+	if opts.archivePath != "" {
+		fsys, opts.path, opts.rootAction, err = getArchiveFS(opts.archivePath, opts.path, opts.rootAction)
+		if err != nil {
+			slog.Error(
+				"error reading archive",
+				slog.String("path", opts.archivePath),
+				slog.String("err", err.Error()),
+			)
+
+			return ExitErrReadingArchive
+		}
+	}
+
 	dotGithub, err := getDotGithub(
 		ctx,
-		path,
-		varsFile,
-		secretsFile,
+		opts.path,
+		opts.varsFile,
+		opts.secretsFile,
+		opts.rootAction,
+		overlay,
+		fsys,
+		profiler,
 		lint.Config.Overrides,
 	)
 	if err != nil && errors.Is(err, errDotGithubDirRead) {
@@ -257,12 +527,37 @@ func lintHandler(ctx context.Context, loglevel string, logmultiline bool, path,
 	}
 
 	outputLimit := 0
-	if outputErrors > 0 {
+	if opts.outputErrors > 0 {
 		// the cli already validates flag
-		outputLimit = outputErrors
+		outputLimit = opts.outputErrors
 	}
 
-	status, err := lint.Lint(dotGithub, output, outputLimit)
+	if opts.changedSince != "" {
+		changedFiles, err := gitcli.GetChangedFiles(ctx, opts.path, opts.changedSince)
+		if err != nil {
+			slog.Error(
+				"error getting changed files",
+				slog.String("ref", opts.changedSince),
+				slog.String("err", err.Error()),
+			)
+
+			return ExitErrGettingChangedFiles
+		}
+
+		opts.files = dotGithub.GetAffectedFiles(changedFiles)
+		slog.Debug(
+			"linting changed files",
+			slog.String("ref", opts.changedSince),
+			slog.Int("changed", len(changedFiles)),
+			slog.Int("affected", len(opts.files)),
+		)
+	}
+
+	if len(opts.files) > 0 || opts.changedSince != "" {
+		lint.Files = opts.files
+	}
+
+	status, err := lint.Lint(ctx, dotGithub, opts.output, outputLimit)
 	if err != nil {
 		slog.Error(
 			"error linting",
@@ -283,11 +578,44 @@ func lintHandler(ctx context.Context, loglevel string, logmultiline bool, path,
 	return 0
 }
 
+// writeProfile prints the profile report to stderr and writes the trace file. Errors are only logged so that they do
+// not change the exit code.
+func writeProfile(profiler *profile.Profiler, profileReport bool, profileTrace string) {
+	if profileReport {
+		err := profiler.WriteReport(os.Stderr, profile.DefaultReportLimit)
+		if err != nil {
+			slog.Error("error writing profile", slog.String("err", err.Error()))
+		}
+	}
+
+	if profileTrace == "" {
+		return
+	}
+
+	traceFile, err := os.Create(filepath.Clean(profileTrace))
+	if err != nil {
+		slog.Error("error creating profile trace", slog.String("path", profileTrace), slog.String("err", err.Error()))
+
+		return
+	}
+
+	defer func() { _ = traceFile.Close() }()
+
+	err = profiler.WriteTrace(traceFile)
+	if err != nil {
+		slog.Error("error writing profile trace", slog.String("path", profileTrace), slog.String("err", err.Error()))
+	}
+}
+
 func getConfigFilePath(filePath string, dotGitHubPath string) (string, error) {
 	if filePath != "" {
 		return filePath, nil
 	}
 
+	if dotGitHubPath == "" {
+		return "", nil
+	}
+
 	configInDotGithub := filepath.Join(dotGitHubPath, configFileName)
 	_, err := os.Stat(configInDotGithub)
 
@@ -364,9 +692,18 @@ func getDotGithub(
 	dotGithubPath string,
 	varsFile string,
 	secretsFile string,
+	rootActionPath string,
+	overlay map[string][]byte,
+	fsys fs.FS,
+	profiler *profile.Profiler,
 	overrides *linter.Overrides,
 ) (*dotgithub.DotGithub, error) {
-	dotGithub := dotgithub.DotGithub{}
+	dotGithub := dotgithub.DotGithub{
+		RootActionPath: rootActionPath,
+		Overlay:        overlay,
+		FS:             fsys,
+		Profiler:       profiler,
+	}
 
 	overridePaths := map[string]string{}
 	overrideOutputs := map[string][]*regexp.Regexp{}
@@ -422,6 +759,98 @@ func getDotGithub(
 	return &dotGithub, nil
 }
 
+// getRootActionPath returns the parent directory of the .github directory, where a repository publishing an action
+// to GitHub Marketplace keeps its action.yml. Returns empty string when the path does not point to a .github directory.
+func getRootActionPath(dotGithubPath string) string {
+	absPath, err := filepath.Abs(dotGithubPath)
+	if err != nil || filepath.Base(absPath) != ".github" {
+		return ""
+	}
+
+	return filepath.Dir(absPath)
+}
+
+// getGitRefFS returns a file system with the .github directory and the root action as they are in the git ref, along
+// with their paths in it.
+func getGitRefFS(ctx context.Context, gitRef, dotGithubPath, rootActionPath string) (fs.FS, string, string, error) {
+	repoRoot, dotGithubRelPath, err := gitcli.GetRepositoryPath(ctx, dotGithubPath)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("error getting .github path in the repository: %w", err)
+	}
+
+	pathspecs := []string{dotGithubRelPath}
+
+	rootActionRelPath := ""
+	if rootActionPath != "" {
+		_, rootActionRelPath, err = gitcli.GetRepositoryPath(ctx, rootActionPath)
+		if err != nil {
+			return nil, "", "", fmt.Errorf("error getting root action path in the repository: %w", err)
+		}
+
+		pathspecs = append(
+			pathspecs,
+			pathpkg.Join(rootActionRelPath, "action.yml"),
+			pathpkg.Join(rootActionRelPath, "action.yaml"),
+		)
+	}
+
+	fsys, err := gitcli.NewFS(ctx, repoRoot, gitRef, pathspecs...)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("error reading files from git: %w", err)
+	}
+
+	return fsys, dotGithubRelPath, rootActionRelPath, nil
+}
+
+// getArchiveFS returns a file system with contents of the archive, along with paths of the .github directory and
+// the root action in it. When the .github path is empty, the shallowest .github directory in the archive is used.
+func getArchiveFS(archivePath, dotGithubPath, rootActionPath string) (fs.FS, string, string, error) {
+	fsys, err := archive.NewFS(archivePath)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("error reading archive: %w", err)
+	}
+
+	if dotGithubPath == "" {
+		dotGithubPath = archive.FindDotGithub(fsys)
+		if dotGithubPath == "" {
+			return nil, "", "", errDotGithubNotInArchive
+		}
+	}
+
+	dotGithubPath = pathpkg.Clean(filepath.ToSlash(dotGithubPath))
+
+	if rootActionPath != "" {
+		rootActionPath = pathpkg.Clean(filepath.ToSlash(rootActionPath))
+	} else if pathpkg.Base(dotGithubPath) == ".github" {
+		rootActionPath = pathpkg.Dir(dotGithubPath)
+	}
+
+	return fsys, dotGithubPath, rootActionPath, nil
+}
+
+// getDotGithubPathFromFiles returns the first .github directory that contains one of the files. Returns empty string
+// when none of the files is inside a .github directory.
+func getDotGithubPathFromFiles(files []string) string {
+	for _, file := range files {
+		if file == "" {
+			continue
+		}
+
+		absPath, err := filepath.Abs(file)
+		if err != nil {
+			continue
+		}
+
+		for dir := filepath.Dir(absPath); dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+			if filepath.Base(dir) == ".github" {
+				return dir
+			}
+		}
+	}
+
+	return ""
+}
+
 func setLogger(loglevelFlag string, multiline bool) {
 	logLevel := loglevel.GetLogLevelFromString(loglevelFlag)
 
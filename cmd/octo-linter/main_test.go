@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// writeDotGithub writes files to a temporary .github directory with a config that does not download external
+// actions, and returns its path.
+func writeDotGithub(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dotGithubPath := filepath.Join(t.TempDir(), ".github")
+
+	files[configFileName] = "version: '3'\nrules:\n  filenames:\n    workflow_filename_extensions_allowed: ['yml']\n"
+
+	for name, content := range files {
+		path := filepath.Join(dotGithubPath, name)
+
+		err := os.MkdirAll(filepath.Dir(path), 0o750)
+		if err != nil {
+			t.Fatalf("cannot create directory: %s", err.Error())
+		}
+
+		err = os.WriteFile(path, []byte(content), 0o600)
+		if err != nil {
+			t.Fatalf("cannot write file: %s", err.Error())
+		}
+	}
+
+	return dotGithubPath
+}
+
+func TestLintHandlerParseError(t *testing.T) {
+	validWorkflow := "name: Main\non: push\njobs:\n  main:\n    runs-on: ubuntu-latest\n"
+
+	for _, tt := range []struct {
+		name     string
+		files    map[string]string
+		exitCode int
+	}{
+		{
+			name:     "valid",
+			files:    map[string]string{"workflows/main.yml": validWorkflow},
+			exitCode: ExitOK,
+		},
+		{
+			name:     "unparsable workflow",
+			files:    map[string]string{"workflows/main.yml": "name: Main\njobs:\n  main: runs-on: ubuntu-latest\n"},
+			exitCode: ExitLintErrors,
+		},
+		{
+			name: "unparsable action",
+			files: map[string]string{
+				"actions/build/action.yml": "name: Build\nruns: [\n",
+				"workflows/main.yml":       validWorkflow,
+			},
+			exitCode: ExitLintErrors,
+		},
+	} {
+		path := writeDotGithub(t, tt.files)
+
+		exitCode := lintHandler(context.Background(), lintOptions{logLevel: "ERR", path: path, jobs: 1})
+		if exitCode != tt.exitCode {
+			t.Errorf("lintHandler on %s files should return exit code %d, got %d", tt.name, tt.exitCode, exitCode)
+		}
+	}
+}
+
+func TestLintHandlerFiles(t *testing.T) {
+	path := writeDotGithub(t, map[string]string{
+		"workflows/main.yml":   "name: Main\non: push\njobs:\n  main:\n    runs-on: ubuntu-latest\n",
+		"workflows/broken.yml": "name: Broken\njobs:\n  main: runs-on: ubuntu-latest\n",
+	})
+
+	for _, tt := range []struct {
+		files    []string
+		exitCode int
+	}{
+		{files: []string{filepath.Join(path, "workflows", "main.yml")}, exitCode: ExitOK},
+		{files: []string{filepath.Join(path, "workflows", "broken.yml")}, exitCode: ExitLintErrors},
+		{files: nil, exitCode: ExitLintErrors},
+	} {
+		exitCode := lintHandler(context.Background(), lintOptions{logLevel: "ERR", path: path, files: tt.files, jobs: 1})
+		if exitCode != tt.exitCode {
+			t.Errorf("lintHandler on files %v should return exit code %d, got %d", tt.files, tt.exitCode, exitCode)
+		}
+	}
+}
+
+func TestGetDotGithubPathFromFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	for _, tt := range []struct {
+		files    []string
+		expected string
+	}{
+		{files: nil, expected: ""},
+		{files: []string{"", filepath.Join(dir, "README.md")}, expected: ""},
+		{
+			files:    []string{filepath.Join(dir, ".github", "workflows", "main.yml")},
+			expected: filepath.Join(dir, ".github"),
+		},
+		{
+			files:    []string{filepath.Join(dir, "src", "main.go"), filepath.Join(dir, ".github", "actions", "a", "action.yml")},
+			expected: filepath.Join(dir, ".github"),
+		},
+		{
+			files:    []string{filepath.Join(dir, "a", ".github", "workflows", "main.yml"), filepath.Join(dir, ".github", "x.yml")},
+			expected: filepath.Join(dir, "a", ".github"),
+		},
+	} {
+		result := getDotGithubPathFromFiles(tt.files)
+		if result != tt.expected {
+			t.Errorf("getDotGithubPathFromFiles(%v) should return '%s', got '%s'", tt.files, tt.expected, result)
+		}
+	}
+}
+
+func TestCheckPath(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.txt")
+
+	err := os.WriteFile(file, []byte("x"), 0o600)
+	if err != nil {
+		t.Fatalf("cannot write file: %s", err.Error())
+	}
+
+	for _, tt := range []struct {
+		path    string
+		isDir   bool
+		isError bool
+	}{
+		{path: file, isDir: false, isError: false},
+		{path: file, isDir: true, isError: true},
+		{path: dir, isDir: true, isError: false},
+		{path: dir, isDir: false, isError: true},
+		{path: filepath.Join(dir, "missing"), isDir: false, isError: true},
+		{path: filepath.Join(dir, "missing"), isDir: true, isError: true},
+		// a path under a file returns an error that is not os.ErrNotExist
+		{path: filepath.Join(file, "sub"), isDir: false, isError: true},
+	} {
+		err := checkPath("file", tt.path, tt.isDir)
+		if (err != nil) != tt.isError {
+			t.Errorf("checkPath(%s, %v) should return an error: %v, got %v", tt.path, tt.isDir, tt.isError, err)
+		}
+	}
+}
+
+func gitCmd(t *testing.T, dir string, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed with an error: %s: %s", args, err.Error(), out)
+	}
+}
+
+func TestLintHandlerGitRef(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	path := writeDotGithub(t, map[string]string{
+		"workflows/main.yml": "name: Main\non: push\njobs:\n  main:\n    runs-on: ubuntu-latest\n",
+	})
+	repo := filepath.Dir(path)
+	commitArgs := []string{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m"}
+
+	gitCmd(t, repo, "init", "-q", "-b", "main")
+	gitCmd(t, repo, "add", "-A")
+	gitCmd(t, repo, append(commitArgs, "valid")...)
+
+	err := os.WriteFile(filepath.Join(path, "workflows", "main.yml"), []byte("name: Main\njobs:\n  main: runs-on: x\n"), 0o600)
+	if err != nil {
+		t.Fatalf("cannot write file: %s", err.Error())
+	}
+
+	gitCmd(t, repo, "add", "-A")
+	gitCmd(t, repo, append(commitArgs, "broken")...)
+
+	// the working tree is fixed but the files are read from the git ref
+	err = os.WriteFile(filepath.Join(path, "workflows", "main.yml"), []byte("name: Main\non: push\njobs: {}\n"), 0o600)
+	if err != nil {
+		t.Fatalf("cannot write file: %s", err.Error())
+	}
+
+	for _, tt := range []struct {
+		gitRef   string
+		exitCode int
+	}{
+		{gitRef: "HEAD~1", exitCode: ExitOK},
+		{gitRef: "HEAD", exitCode: ExitLintErrors},
+		{gitRef: "missing", exitCode: ExitErrReadingGitRef},
+	} {
+		exitCode := lintHandler(context.Background(), lintOptions{
+			logLevel:   "ERR",
+			path:       path,
+			rootAction: repo,
+			gitRef:     tt.gitRef,
+			jobs:       1,
+		})
+		if exitCode != tt.exitCode {
+			t.Errorf("lintHandler with git ref '%s' should return exit code %d, got %d", tt.gitRef, tt.exitCode, exitCode)
+		}
+	}
+}
@@ -9,10 +9,11 @@ import (
 	"text/template"
 )
 
-// S represents a struct that is about to be generated.
+// S represents a struct that is about to be generated. V2 is set for rules implementing rule.RuleV2.
 type S struct {
-	N string
-	F map[string]string
+	N  string
+	F  map[string]string
+	V2 bool
 }
 
 const (
@@ -49,7 +50,20 @@ func main() {
 				N: "filenames.WorkflowFilenameBaseFormat",
 			},
 			"workflow_runners__not_latest": {
-				N: "runners.NotLatest",
+				N:  "runners.NotLatest",
+				V2: true,
+			},
+			"workflow_runners__self_hosted_not_on_fork_pull_requests": {
+				N:  "runners.SelfHostedNotOnForkPullRequests",
+				V2: true,
+			},
+			"workflow_runners__labels_allowed": {
+				N:  "runners.LabelsAllowed",
+				V2: true,
+			},
+			"workflow_runners__deprecated_images": {
+				N:  "runners.DeprecatedImages",
+				V2: true,
 			},
 			"referenced_variables_in_actions__not_one_word": {
 				N: "refvars.NotOneWord",
@@ -96,6 +110,16 @@ func main() {
 				N: "usedactions.ValidInputs",
 				F: map[string]string{"FileTypeRequired": `"action"`},
 			},
+			"used_actions_in_action_steps__must_be_pinned": {
+				N:  "usedactions.MustBePinned",
+				F:  map[string]string{"FileTypeRequired": `"action"`},
+				V2: true,
+			},
+			"used_actions_in_action_steps__must_not_have_advisories": {
+				N:  "usedactions.MustNotHaveAdvisories",
+				F:  map[string]string{"FileTypeRequired": `"action"`, "ConfigDir": "cfg.dir"},
+				V2: true,
+			},
 			"used_actions_in_workflow_job_steps__source": {
 				N: "usedactions.Source",
 				F: map[string]string{"FileTypeRequired": `"workflow"`},
@@ -108,6 +132,16 @@ func main() {
 				N: "usedactions.ValidInputs",
 				F: map[string]string{"FileTypeRequired": `"workflow"`},
 			},
+			"used_actions_in_workflow_job_steps__must_be_pinned": {
+				N:  "usedactions.MustBePinned",
+				F:  map[string]string{"FileTypeRequired": `"workflow"`},
+				V2: true,
+			},
+			"used_actions_in_workflow_job_steps__must_not_have_advisories": {
+				N:  "usedactions.MustNotHaveAdvisories",
+				F:  map[string]string{"FileTypeRequired": `"workflow"`, "ConfigDir": "cfg.dir"},
+				V2: true,
+			},
 			"naming_conventions__action_input_name_format": {
 				N: "naming.Action",
 				F: map[string]string{"Field": `naming.ActionFieldInputName`},
@@ -182,6 +216,85 @@ func main() {
 			"required_fields__workflow_requires_uses_or_runs_on_required": {
 				N: "required.WorkflowUsesOrRunsOn",
 			},
+			"action_runs__using_required_fields": {
+				N: "actionruns.UsingRequiredFields",
+			},
+			"action_runs__deprecated_runtimes": {
+				N: "actionruns.DeprecatedRuntimes",
+			},
+			"action_runs__referenced_files_exist": {
+				N: "actionruns.ReferencedFilesExist",
+			},
+			"action_runs__composite_run_step_requires_shell": {
+				N: "actionruns.CompositeRunStepRequiresShell",
+			},
+			"marketplace__action_branding_valid": {
+				N: "marketplace.BrandingValid",
+			},
+			"script_injection__action_untrusted_contexts_not_allowed": {
+				N:  "injection.UntrustedContexts",
+				F:  map[string]string{"FileTypeRequired": `"action"`},
+				V2: true,
+			},
+			"script_injection__workflow_untrusted_contexts_not_allowed": {
+				N:  "injection.UntrustedContexts",
+				F:  map[string]string{"FileTypeRequired": `"workflow"`},
+				V2: true,
+			},
+			"privileged_triggers__workflow_untrusted_code_not_allowed": {
+				N:  "triggers.PrivilegedUntrustedCode",
+				V2: true,
+			},
+			"hardcoded_credentials__action_not_allowed": {
+				N:  "credentials.Hardcoded",
+				F:  map[string]string{"FileTypeRequired": `"action"`},
+				V2: true,
+			},
+			"hardcoded_credentials__workflow_not_allowed": {
+				N:  "credentials.Hardcoded",
+				F:  map[string]string{"FileTypeRequired": `"workflow"`},
+				V2: true,
+			},
+			"leaking_secrets__action_run_not_allowed": {
+				N:  "leaks.SecretsInRun",
+				F:  map[string]string{"FileTypeRequired": `"action"`},
+				V2: true,
+			},
+			"leaking_secrets__workflow_run_not_allowed": {
+				N:  "leaks.SecretsInRun",
+				F:  map[string]string{"FileTypeRequired": `"workflow"`},
+				V2: true,
+			},
+			"leaking_secrets__workflow_env_not_allowed": {
+				N:  "leaks.SecretsInWorkflowEnv",
+				V2: true,
+			},
+			"remote_scripts__action_unsafe_execution_not_allowed": {
+				N:  "remotecode.UnsafeExecution",
+				F:  map[string]string{"FileTypeRequired": `"action"`},
+				V2: true,
+			},
+			"remote_scripts__workflow_unsafe_execution_not_allowed": {
+				N:  "remotecode.UnsafeExecution",
+				F:  map[string]string{"FileTypeRequired": `"workflow"`},
+				V2: true,
+			},
+			"permissions__workflow_requires_permissions": {
+				N:  "permissions.Required",
+				V2: true,
+			},
+			"permissions__workflow_shorthands_not_allowed": {
+				N:  "permissions.ShorthandsNotAllowed",
+				V2: true,
+			},
+			"permissions__workflow_write_scopes_allowed": {
+				N:  "permissions.WriteScopesAllowed",
+				V2: true,
+			},
+			"permissions__workflow_id_token_write_requires_oidc_action": {
+				N:  "permissions.IDTokenWriteRequiresOIDCAction",
+				V2: true,
+			},
 		},
 	}
 
@@ -194,7 +307,7 @@ func main() {
 
 	fileRules, err := os.OpenFile(
 		filepath.Join(filepath.Clean(genPath), "internal", "linter", "generated_config_rules.go"),
-		os.O_RDWR|os.O_CREATE,
+		os.O_RDWR|os.O_CREATE|os.O_TRUNC,
 		FileModeConfigRules,
 	)
 	if err != nil {
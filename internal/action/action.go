@@ -3,8 +3,6 @@ package action
 
 import (
 	"fmt"
-	"log/slog"
-	"os"
 	"regexp"
 
 	"gopkg.in/yaml.v2"
@@ -26,24 +24,11 @@ type Action struct {
 	Outputs        map[string]*Output `yaml:"outputs"`
 	DynamicOutputs []*regexp.Regexp   `yaml:"-"`
 	Runs           *Runs              `yaml:"runs"`
+	Branding       *Branding          `yaml:"branding"`
 }
 
-// Unmarshal parses YAML from a file in struct's Path or from struct's Raw field.
-func (a *Action) Unmarshal(fromRaw bool) error {
-	if !fromRaw {
-		slog.Debug(
-			"reading action file",
-			slog.String("path", a.Path),
-		)
-
-		b, err := os.ReadFile(a.Path)
-		if err != nil {
-			return fmt.Errorf("cannot read file %s: %w", a.Path, err)
-		}
-
-		a.Raw = b
-	}
-
+// Unmarshal parses YAML from struct's Raw field.
+func (a *Action) Unmarshal() error {
 	err := yaml.Unmarshal(a.Raw, &a)
 	if err != nil {
 		return fmt.Errorf("cannot unmarshal file %s: %w", a.Path, err)
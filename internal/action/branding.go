@@ -0,0 +1,8 @@
+package action
+
+// Branding represents a 'branding' field in a GitHub Actions action parsed from YAML. It is used by the GitHub
+// Marketplace to display the action's badge.
+type Branding struct {
+	Icon  string `yaml:"icon"`
+	Color string `yaml:"color"`
+}
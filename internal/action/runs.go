@@ -1,13 +1,44 @@
 package action
 
 import (
+	"strings"
+
 	"octo-linter/internal/step"
 )
 
+const (
+	// UsingComposite is the 'using' value of a composite action.
+	UsingComposite = "composite"
+	// UsingDocker is the 'using' value of a Docker container action.
+	UsingDocker = "docker"
+	// UsingNode12 is the 'using' value of a JavaScript action running on Node.js 12.
+	UsingNode12 = "node12"
+	// UsingNode16 is the 'using' value of a JavaScript action running on Node.js 16.
+	UsingNode16 = "node16"
+	// UsingNode20 is the 'using' value of a JavaScript action running on Node.js 20.
+	UsingNode20 = "node20"
+	// UsingNode24 is the 'using' value of a JavaScript action running on Node.js 24.
+	UsingNode24 = "node24"
+)
+
+// DockerImagePrefix is the prefix of a Docker 'image' that points to a public registry instead of a Dockerfile.
+const DockerImagePrefix = "docker://"
+
 // Runs represents a 'runs' field in a GitHub Actions action parsed from YAML.
 type Runs struct {
-	Using string       `yaml:"using"`
-	Steps []*step.Step `yaml:"steps"`
+	Using          string            `yaml:"using"`
+	Steps          []*step.Step      `yaml:"steps"`
+	Main           string            `yaml:"main"`
+	Pre            string            `yaml:"pre"`
+	Post           string            `yaml:"post"`
+	PreIf          string            `yaml:"pre-if"`
+	PostIf         string            `yaml:"post-if"`
+	Image          string            `yaml:"image"`
+	Entrypoint     string            `yaml:"entrypoint"`
+	PreEntrypoint  string            `yaml:"pre-entrypoint"`
+	PostEntrypoint string            `yaml:"post-entrypoint"`
+	Args           []string          `yaml:"args"`
+	Env            map[string]string `yaml:"env"`
 }
 
 // SetParentType sets type of the parent for all of the steps.
@@ -27,3 +58,24 @@ func (ar *Runs) GetStep(id string) *step.Step {
 
 	return nil
 }
+
+// IsComposite checks whether the action is a composite action.
+func (ar *Runs) IsComposite() bool {
+	return ar.Using == UsingComposite
+}
+
+// IsDocker checks whether the action is a Docker container action.
+func (ar *Runs) IsDocker() bool {
+	return ar.Using == UsingDocker
+}
+
+// IsNode checks whether the action is a JavaScript action, regardless of the Node.js version.
+func (ar *Runs) IsNode() bool {
+	return strings.HasPrefix(ar.Using, "node")
+}
+
+// UsesDockerfile checks whether the Docker action is built from a Dockerfile in the action directory rather than
+// pulled from a registry.
+func (ar *Runs) UsesDockerfile() bool {
+	return ar.IsDocker() && ar.Image != "" && !strings.HasPrefix(ar.Image, DockerImagePrefix)
+}
@@ -0,0 +1,274 @@
+// Package advisory contains code related to advisories published for GitHub Actions actions, such as compromised or
+// vulnerable versions.
+package advisory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// FileModeDatabase sets the mode for the advisory file written by the 'advisories import' command.
+	FileModeDatabase = 0o644
+)
+
+var (
+	errAdvisoryInvalid = errors.New("invalid advisory")
+	errRangeInvalid    = errors.New("invalid version range")
+)
+
+var (
+	regexpConstraint = regexp.MustCompile(`^(<=|>=|<|>|=)?\s*(\S+)$`)
+	regexpVersion    = regexp.MustCompile(`^v?([0-9]+(\.[0-9]+)*)(-[0-9A-Za-z\.\-]+)?$`)
+	regexpFullSHA    = regexp.MustCompile(`^[0-9a-f]{40}$`)
+)
+
+// Database is a list of advisories read from a local file.
+type Database struct {
+	Advisories []Advisory `json:"advisories" yaml:"advisories"`
+}
+
+// Advisory describes versions of an action that should not be used.
+type Advisory struct {
+	ID string `json:"id" yaml:"id"`
+	// Action is the path of the action, eg. 'tj-actions/changed-files'. Actions in subdirectories of the repository,
+	// eg. 'github/codeql-action/init', are affected by an advisory for the repository as well.
+	Action string `json:"action" yaml:"action"`
+	// Ranges contains affected version ranges, each being comma-separated constraints, eg. '>= 1.0.0, < 1.2.3'. An
+	// empty list means all versions are affected.
+	Ranges []string `json:"ranges,omitempty" yaml:"ranges,omitempty"`
+	// Refs contains affected refs that are not versions, eg. commit SHAs of a compromised release.
+	Refs     []string `json:"refs,omitempty" yaml:"refs,omitempty"`
+	Severity string   `json:"severity,omitempty" yaml:"severity,omitempty"`
+	Summary  string   `json:"summary,omitempty" yaml:"summary,omitempty"`
+	URL      string   `json:"url,omitempty" yaml:"url,omitempty"`
+}
+
+// Load reads advisories from a YAML or JSON file.
+func Load(path string) (Database, error) {
+	var database Database
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return database, fmt.Errorf("cannot read advisory file %s: %w", path, err)
+	}
+
+	// JSON is read as YAML as well
+	err = yaml.Unmarshal(b, &database)
+	if err != nil {
+		return database, fmt.Errorf("cannot unmarshal advisory file %s: %w", path, err)
+	}
+
+	for i, advisory := range database.Advisories {
+		err = advisory.validate()
+		if err != nil {
+			return database, fmt.Errorf("advisory %d in %s: %w", i+1, path, err)
+		}
+	}
+
+	return database, nil
+}
+
+// Write writes advisories to a file, as JSON when its name ends with '.json', and as YAML otherwise.
+func (d Database) Write(path string) error {
+	var (
+		b   []byte
+		err error
+	)
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		b, err = json.MarshalIndent(d, "", "  ")
+		b = append(b, '\n')
+	} else {
+		b, err = yaml.Marshal(d)
+	}
+
+	if err != nil {
+		return fmt.Errorf("cannot marshal advisories: %w", err)
+	}
+
+	err = os.WriteFile(path, b, FileModeDatabase)
+	if err != nil {
+		return fmt.Errorf("cannot write advisory file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Find returns advisories affecting the action called with 'uses', eg. 'tj-actions/changed-files@v45'. When the ref
+// is a commit SHA, version is used to match the ranges, eg. from a '# v45.0.7' comment after it.
+func (d Database) Find(uses string, version string) []Advisory {
+	actionPath, ref, _ := strings.Cut(uses, "@")
+	actionPath = strings.ToLower(actionPath)
+
+	found := []Advisory{}
+
+	for _, advisory := range d.Advisories {
+		action := strings.ToLower(advisory.Action)
+		if actionPath != action && !strings.HasPrefix(actionPath, action+"/") {
+			continue
+		}
+
+		if advisory.affects(ref, version) {
+			found = append(found, advisory)
+		}
+	}
+
+	return found
+}
+
+func (a Advisory) validate() error {
+	if a.ID == "" || a.Action == "" {
+		return fmt.Errorf("%w: 'id' and 'action' are required", errAdvisoryInvalid)
+	}
+
+	for _, versionRange := range a.Ranges {
+		_, err := parseRange(versionRange)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a Advisory) affects(ref string, version string) bool {
+	for _, affectedRef := range a.Refs {
+		if strings.EqualFold(ref, affectedRef) {
+			return true
+		}
+	}
+
+	if !regexpFullSHA.MatchString(ref) {
+		version = ref
+	}
+
+	parsedVersion, ok := parseVersion(version)
+	if !ok {
+		// branches, and commit SHAs without a version, cannot be matched
+		return len(a.Ranges) == 0 && len(a.Refs) == 0 && ref != ""
+	}
+
+	if len(a.Ranges) == 0 {
+		return true
+	}
+
+	for _, versionRange := range a.Ranges {
+		constraints, err := parseRange(versionRange)
+		if err == nil && constraints.match(parsedVersion) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type constraint struct {
+	op      string
+	version []int
+}
+
+type constraints []constraint
+
+func (c constraints) match(version []int) bool {
+	for _, constr := range c {
+		cmp := compareVersions(version, constr.version)
+
+		var ok bool
+
+		switch constr.op {
+		case "<":
+			ok = cmp < 0
+		case "<=":
+			ok = cmp <= 0
+		case ">":
+			ok = cmp > 0
+		case ">=":
+			ok = cmp >= 0
+		default:
+			ok = cmp == 0
+		}
+
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseRange parses comma-separated constraints, eg. '>= 1.0.0, < 1.2.3'.
+func parseRange(versionRange string) (constraints, error) {
+	parsed := constraints{}
+
+	for _, part := range strings.Split(versionRange, ",") {
+		match := regexpConstraint.FindStringSubmatch(strings.TrimSpace(part))
+		if match == nil {
+			return nil, fmt.Errorf("%w: '%s'", errRangeInvalid, versionRange)
+		}
+
+		version, ok := parseVersion(match[2])
+		if !ok {
+			return nil, fmt.Errorf("%w: '%s'", errRangeInvalid, versionRange)
+		}
+
+		parsed = append(parsed, constraint{op: match[1], version: version})
+	}
+
+	return parsed, nil
+}
+
+// parseVersion returns numeric parts of a version, eg. [4 1 2] for 'v4.1.2'. A pre-release suffix is ignored.
+func parseVersion(version string) ([]int, bool) {
+	match := regexpVersion.FindStringSubmatch(version)
+	if match == nil {
+		return nil, false
+	}
+
+	parts := strings.Split(match[1], ".")
+	parsed := make([]int, 0, len(parts))
+
+	for _, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, false
+		}
+
+		parsed = append(parsed, number)
+	}
+
+	return parsed, true
+}
+
+// compareVersions compares versions, treating missing parts as 0, eg. 'v4' is equal to '4.0.0'.
+func compareVersions(a []int, b []int) int {
+	for i := range max(len(a), len(b)) {
+		var partA, partB int
+
+		if i < len(a) {
+			partA = a[i]
+		}
+
+		if i < len(b) {
+			partB = b[i]
+		}
+
+		if partA != partB {
+			if partA < partB {
+				return -1
+			}
+
+			return 1
+		}
+	}
+
+	return 0
+}
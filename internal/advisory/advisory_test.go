@@ -0,0 +1,123 @@
+package advisory
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	t.Parallel()
+
+	database, err := Load("../../tests/advisories/advisories.yml")
+	if err != nil {
+		t.Fatalf("Load failed with an error: %s", err.Error())
+	}
+
+	for _, tt := range []struct {
+		uses     string
+		version  string
+		expected int
+	}{
+		{uses: "tj-actions/changed-files@v45", expected: 1},
+		{uses: "TJ-Actions/Changed-Files@v45.0.7", expected: 1},
+		{uses: "tj-actions/changed-files@v46.0.1", expected: 0},
+		{uses: "tj-actions/changed-files@0e58ed8671d6b60d0890c21b07f8835ace038e67", expected: 1},
+		{uses: "tj-actions/changed-files@8e5e7e5ab8b370d6c329ec480221332ada57f0ab", version: "v45.0.7", expected: 1},
+		{uses: "tj-actions/changed-files@8e5e7e5ab8b370d6c329ec480221332ada57f0ab", expected: 0},
+		{uses: "tj-actions/changed-files@main", expected: 0},
+		{uses: "tj-actions/changed-files-other@v1", expected: 0},
+		{uses: "org/repo/sub@v2.3.0", expected: 1},
+		{uses: "org/repo@v2.3.1", expected: 0},
+		{uses: "org/repo@1.5", expected: 1},
+		{uses: "org/repo@v1.9.9", expected: 0},
+	} {
+		found := database.Find(tt.uses, tt.version)
+		if len(found) != tt.expected {
+			t.Errorf("Find(%s, %s) should return %d advisories, got %+v", tt.uses, tt.version, tt.expected, found)
+		}
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, advisory := range []Advisory{
+		{Action: "org/repo"},
+		{ID: "GHSA-0000-0000-0001", Action: "org/repo", Ranges: []string{"~> 1.0"}},
+		{ID: "GHSA-0000-0000-0001", Action: "org/repo", Ranges: []string{">= 1.0, < main"}},
+	} {
+		path := filepath.Join(t.TempDir(), "advisories.json")
+
+		err := Database{Advisories: []Advisory{advisory}}.Write(path)
+		if err != nil {
+			t.Fatalf("Write failed with an error: %s", err.Error())
+		}
+
+		_, err = Load(path)
+		if err == nil {
+			t.Errorf("Load should return error when an advisory is %+v", advisory)
+		}
+	}
+}
+
+func TestImport(t *testing.T) {
+	t.Parallel()
+
+	database, err := Import([]string{"../../tests/advisories/osv", "../../tests/advisories/api.json"})
+	if err != nil {
+		t.Fatalf("Import failed with an error: %s", err.Error())
+	}
+
+	expected := []Advisory{
+		{
+			ID:       "GHSA-0000-0000-0003",
+			Action:   "org/repo",
+			Ranges:   []string{">= 2.0.0, < 2.3.1"},
+			Severity: "critical",
+			Summary:  "Test advisory from the API",
+			URL:      "https://github.com/advisories/GHSA-0000-0000-0003",
+		},
+		{
+			ID:       "GHSA-mrrh-fwg8-r2c3",
+			Action:   "tj-actions/changed-files",
+			Ranges:   []string{"< 46.0.1"},
+			Severity: "high",
+			Summary:  "tj-actions/changed-files has been compromised",
+			URL:      "https://github.com/advisories/GHSA-mrrh-fwg8-r2c3",
+		},
+	}
+
+	if !reflect.DeepEqual(database.Advisories, expected) {
+		t.Errorf("Import should return advisories for actions only, got %+v", database.Advisories)
+	}
+
+	path := filepath.Join(t.TempDir(), "advisories.yml")
+
+	err = database.Write(path)
+	if err != nil {
+		t.Fatalf("Write failed with an error: %s", err.Error())
+	}
+
+	loaded, err := Load(path)
+	if err != nil || !reflect.DeepEqual(loaded, database) {
+		t.Errorf("Load should read the written advisories, got %+v and %v", loaded, err)
+	}
+}
+
+func TestConvertEvents(t *testing.T) {
+	t.Parallel()
+
+	ranges := convertEvents([]map[string]string{
+		{"introduced": "1.0.0"},
+		{"fixed": "1.2.0"},
+		{"introduced": "2.0.0"},
+		{"last_affected": "2.1.0"},
+		{"introduced": "3.0.0"},
+	})
+
+	expected := []string{">= 1.0.0, < 1.2.0", ">= 2.0.0, <= 2.1.0", ">= 3.0.0"}
+	if !reflect.DeepEqual(ranges, expected) {
+		t.Errorf("convertEvents should return %v, got %v", expected, ranges)
+	}
+}
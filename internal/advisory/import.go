@@ -0,0 +1,269 @@
+package advisory
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	// ecosystemOSV is the ecosystem of actions in OSV files of the GitHub Advisory Database.
+	ecosystemOSV = "GitHub Actions"
+	// ecosystemAPI is the ecosystem of actions in responses of the GitHub global security advisories API.
+	ecosystemAPI = "actions"
+
+	advisoryURLPrefix = "https://github.com/advisories/"
+)
+
+// osvAdvisory is an advisory in OSV format, as in the github/advisory-database repository.
+type osvAdvisory struct {
+	ID        string `json:"id"`
+	Summary   string `json:"summary"`
+	Withdrawn string `json:"withdrawn"`
+	Affected  []struct {
+		Package struct {
+			Ecosystem string `json:"ecosystem"`
+			Name      string `json:"name"`
+		} `json:"package"`
+		Ranges []struct {
+			Events []map[string]string `json:"events"`
+		} `json:"ranges"`
+		Versions []string `json:"versions"`
+	} `json:"affected"`
+	References []struct {
+		Type string `json:"type"`
+		URL  string `json:"url"`
+	} `json:"references"`
+	DatabaseSpecific struct {
+		Severity string `json:"severity"`
+	} `json:"database_specific"`
+}
+
+// apiAdvisory is an advisory as returned by the GitHub global security advisories API.
+type apiAdvisory struct {
+	GHSAID          string `json:"ghsa_id"`
+	Summary         string `json:"summary"`
+	Severity        string `json:"severity"`
+	HTMLURL         string `json:"html_url"`
+	WithdrawnAt     string `json:"withdrawn_at"`
+	Vulnerabilities []struct {
+		Package struct {
+			Ecosystem string `json:"ecosystem"`
+			Name      string `json:"name"`
+		} `json:"package"`
+		VulnerableVersionRange string `json:"vulnerable_version_range"`
+	} `json:"vulnerabilities"`
+}
+
+// Import converts a GitHub Advisory Database export into a Database with advisories for actions only. Each path can
+// be a JSON file or a directory, eg. a clone of the github/advisory-database repository, that is searched for JSON
+// files. A file can contain an advisory in OSV format, or a list of advisories returned by the GitHub API.
+func Import(paths []string) (Database, error) {
+	var database Database
+
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(filePath string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if entry.IsDir() || !strings.EqualFold(filepath.Ext(filePath), ".json") {
+				return nil
+			}
+
+			advisories, err := importFile(filePath)
+			if err != nil {
+				return err
+			}
+
+			for _, advisory := range advisories {
+				err = advisory.validate()
+				if err != nil {
+					slog.Warn("skipping advisory", slog.String("path", filePath), slog.String("err", err.Error()))
+
+					continue
+				}
+
+				database.Advisories = append(database.Advisories, advisory)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return database, fmt.Errorf("cannot import advisories from %s: %w", path, err)
+		}
+	}
+
+	slices.SortFunc(database.Advisories, func(a, b Advisory) int {
+		if a.ID != b.ID {
+			return strings.Compare(a.ID, b.ID)
+		}
+
+		return strings.Compare(a.Action, b.Action)
+	})
+
+	return database, nil
+}
+
+func importFile(path string) ([]Advisory, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read %s: %w", path, err)
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte("[")) {
+		var apiAdvisories []apiAdvisory
+
+		err = json.Unmarshal(b, &apiAdvisories)
+		if err != nil {
+			return nil, fmt.Errorf("cannot unmarshal %s: %w", path, err)
+		}
+
+		advisories := []Advisory{}
+		for _, apiAdv := range apiAdvisories {
+			advisories = append(advisories, apiAdv.convert()...)
+		}
+
+		return advisories, nil
+	}
+
+	var osvAdv osvAdvisory
+
+	err = json.Unmarshal(b, &osvAdv)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal %s: %w", path, err)
+	}
+
+	return osvAdv.convert(), nil
+}
+
+func (o osvAdvisory) convert() []Advisory {
+	if o.Withdrawn != "" {
+		return nil
+	}
+
+	url := advisoryURLPrefix + o.ID
+
+	for _, reference := range o.References {
+		if reference.Type == "ADVISORY" && strings.HasPrefix(reference.URL, advisoryURLPrefix) {
+			url = reference.URL
+
+			break
+		}
+	}
+
+	advisories := []Advisory{}
+
+	for _, affected := range o.Affected {
+		if affected.Package.Ecosystem != ecosystemOSV {
+			continue
+		}
+
+		ranges := []string{}
+		for _, osvRange := range affected.Ranges {
+			ranges = append(ranges, convertEvents(osvRange.Events)...)
+		}
+
+		if len(ranges) == 0 {
+			for _, version := range affected.Versions {
+				ranges = append(ranges, "= "+version)
+			}
+		}
+
+		advisories = append(advisories, Advisory{
+			ID:       o.ID,
+			Action:   affected.Package.Name,
+			Ranges:   ranges,
+			Severity: strings.ToLower(o.DatabaseSpecific.Severity),
+			Summary:  o.Summary,
+			URL:      url,
+		})
+	}
+
+	return advisories
+}
+
+// convertEvents converts OSV range events, eg. 'introduced: 0' followed by 'fixed: 1.2.3', into version ranges,
+// eg. '< 1.2.3'.
+func convertEvents(events []map[string]string) []string {
+	ranges := []string{}
+	introduced := ""
+	open := false
+
+	for _, event := range events {
+		switch {
+		case event["introduced"] != "":
+			introduced = event["introduced"]
+			open = true
+		case event["fixed"] != "" && open:
+			ranges = append(ranges, joinConstraints(introduced, "< "+event["fixed"]))
+			open = false
+		case event["last_affected"] != "" && open:
+			ranges = append(ranges, joinConstraints(introduced, "<= "+event["last_affected"]))
+			open = false
+		}
+	}
+
+	if open {
+		ranges = append(ranges, joinConstraints(introduced, ""))
+	}
+
+	return ranges
+}
+
+func joinConstraints(introduced string, upper string) string {
+	if introduced == "0" || introduced == "" {
+		if upper == "" {
+			return ">= 0"
+		}
+
+		return upper
+	}
+
+	if upper == "" {
+		return ">= " + introduced
+	}
+
+	return ">= " + introduced + ", " + upper
+}
+
+func (a apiAdvisory) convert() []Advisory {
+	if a.WithdrawnAt != "" {
+		return nil
+	}
+
+	url := a.HTMLURL
+	if url == "" {
+		url = advisoryURLPrefix + a.GHSAID
+	}
+
+	advisories := []Advisory{}
+
+	for _, vulnerability := range a.Vulnerabilities {
+		if vulnerability.Package.Ecosystem != ecosystemAPI {
+			continue
+		}
+
+		advisory := Advisory{
+			ID:       a.GHSAID,
+			Action:   vulnerability.Package.Name,
+			Severity: strings.ToLower(a.Severity),
+			Summary:  a.Summary,
+			URL:      url,
+		}
+
+		if vulnerability.VulnerableVersionRange != "" {
+			advisory.Ranges = []string{vulnerability.VulnerableVersionRange}
+		}
+
+		advisories = append(advisories, advisory)
+	}
+
+	return advisories
+}
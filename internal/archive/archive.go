@@ -0,0 +1,198 @@
+// Package archive reads a zip or tar archive, eg. a repository downloaded from GitHub, into an in-memory fs.FS.
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"octo-linter/internal/memfs"
+)
+
+// MaxFileSize is the maximum size of a file whose contents are read from the archive. Larger files can only be
+// listed and stat'ed.
+const MaxFileSize = 10 << 20
+
+var errUnsupportedArchive = errors.New("unsupported archive, expected .zip, .tar, .tar.gz or .tgz")
+
+// NewFS reads the archive at the given path. Only contents of files that are inside a '.github' directory, and of
+// 'action.yml' and 'action.yaml' files, are read. Remaining files can only be listed and stat'ed.
+func NewFS(archivePath string) (*memfs.FS, error) {
+	lowerPath := strings.ToLower(archivePath)
+
+	switch {
+	case strings.HasSuffix(lowerPath, ".zip"):
+		return readZip(archivePath)
+	case strings.HasSuffix(lowerPath, ".tar.gz"), strings.HasSuffix(lowerPath, ".tgz"):
+		return readTar(archivePath, true)
+	case strings.HasSuffix(lowerPath, ".tar"):
+		return readTar(archivePath, false)
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedArchive, archivePath)
+	}
+}
+
+// FindDotGithub returns the shallowest '.github' directory in the file system, or empty string when there is none.
+// Archives downloaded from GitHub contain a single top-level directory with the repository in it.
+func FindDotGithub(fsys *memfs.FS) string {
+	dirs := []string{"."}
+
+	for len(dirs) > 0 {
+		next := []string{}
+
+		for _, dir := range dirs {
+			entries, err := fsys.ReadDir(dir)
+			if err != nil {
+				continue
+			}
+
+			for _, entry := range entries {
+				if !entry.IsDir() {
+					continue
+				}
+
+				if entry.Name() == ".github" {
+					return path.Join(dir, entry.Name())
+				}
+
+				next = append(next, path.Join(dir, entry.Name()))
+			}
+		}
+
+		dirs = next
+	}
+
+	return ""
+}
+
+func readZip(archivePath string) (*memfs.FS, error) {
+	reader, err := zip.OpenReader(filepath.Clean(archivePath))
+	if err != nil {
+		return nil, fmt.Errorf("error opening zip archive %s: %w", archivePath, err)
+	}
+
+	defer func() { _ = reader.Close() }()
+
+	fsys := memfs.New()
+
+	for _, zipFile := range reader.File {
+		name, ok := getName(zipFile.Name)
+		if !ok || !zipFile.Mode().IsRegular() {
+			continue
+		}
+
+		if !isRead(name, zipFile.UncompressedSize64) {
+			_ = fsys.AddFileInfo(name, int64(min(zipFile.UncompressedSize64, math.MaxInt64)))
+
+			continue
+		}
+
+		b, err := readZipFile(zipFile)
+		if err != nil {
+			return nil, err
+		}
+
+		_ = fsys.AddFile(name, b)
+	}
+
+	return fsys, nil
+}
+
+func readZipFile(zipFile *zip.File) ([]byte, error) {
+	fileReader, err := zipFile.Open()
+	if err != nil {
+		return nil, fmt.Errorf("error opening %s in zip archive: %w", zipFile.Name, err)
+	}
+
+	defer func() { _ = fileReader.Close() }()
+
+	b, err := io.ReadAll(io.LimitReader(fileReader, MaxFileSize))
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s in zip archive: %w", zipFile.Name, err)
+	}
+
+	return b, nil
+}
+
+func readTar(archivePath string, gzipped bool) (*memfs.FS, error) {
+	archiveFile, err := os.Open(filepath.Clean(archivePath))
+	if err != nil {
+		return nil, fmt.Errorf("error opening tar archive %s: %w", archivePath, err)
+	}
+
+	defer func() { _ = archiveFile.Close() }()
+
+	var archiveReader io.Reader = archiveFile
+
+	if gzipped {
+		gzipReader, err := gzip.NewReader(archiveFile)
+		if err != nil {
+			return nil, fmt.Errorf("error opening gzip stream of %s: %w", archivePath, err)
+		}
+
+		defer func() { _ = gzipReader.Close() }()
+
+		archiveReader = gzipReader
+	}
+
+	tarReader := tar.NewReader(archiveReader)
+	fsys := memfs.New()
+
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return fsys, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("error reading tar archive %s: %w", archivePath, err)
+		}
+
+		name, ok := getName(header.Name)
+		if !ok || header.Typeflag != tar.TypeReg || header.Size < 0 {
+			continue
+		}
+
+		if !isRead(name, uint64(header.Size)) {
+			_ = fsys.AddFileInfo(name, header.Size)
+
+			continue
+		}
+
+		b, err := io.ReadAll(io.LimitReader(tarReader, MaxFileSize))
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s in tar archive: %w", header.Name, err)
+		}
+
+		_ = fsys.AddFile(name, b)
+	}
+}
+
+// getName returns the name of an archive entry as a path that is valid in fs.FS.
+func getName(entryName string) (string, bool) {
+	name := path.Clean(strings.TrimPrefix(strings.ReplaceAll(entryName, "\\", "/"), "/"))
+
+	return name, fs.ValidPath(name) && name != "."
+}
+
+func isRead(name string, size uint64) bool {
+	if size > MaxFileSize {
+		return false
+	}
+
+	baseName := path.Base(name)
+	if baseName == "action.yml" || baseName == "action.yaml" {
+		return true
+	}
+
+	return strings.HasPrefix(name, ".github/") || strings.Contains(name, "/.github/")
+}
@@ -0,0 +1,226 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type entry struct {
+	name string
+	b    []byte
+}
+
+func getEntries() []entry {
+	return []entry{
+		{name: "repo-main/.github/workflows/main.yml", b: []byte("name: Main\n")},
+		{name: "repo-main/action.yml", b: []byte("name: Root\n")},
+		{name: "repo-main/README.md", b: []byte("# Repo\n")},
+		{name: "repo-main/.github/large.yml", b: make([]byte, MaxFileSize+1)},
+		{name: "../evil/.github/workflows/main.yml", b: []byte("name: Evil\n")},
+		{name: "/repo-main/.github/actions/build/action.yml", b: []byte("name: Build\n")},
+	}
+}
+
+func writeZip(t *testing.T, archivePath string, entries []entry) {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	writer := zip.NewWriter(&buf)
+
+	for _, e := range entries {
+		fileWriter, err := writer.Create(e.name)
+		if err != nil {
+			t.Fatalf("cannot create %s in zip archive: %s", e.name, err.Error())
+		}
+
+		_, err = fileWriter.Write(e.b)
+		if err != nil {
+			t.Fatalf("cannot write %s in zip archive: %s", e.name, err.Error())
+		}
+	}
+
+	err := writer.Close()
+	if err != nil {
+		t.Fatalf("cannot close zip archive: %s", err.Error())
+	}
+
+	err = os.WriteFile(archivePath, buf.Bytes(), 0o600)
+	if err != nil {
+		t.Fatalf("cannot write zip archive: %s", err.Error())
+	}
+}
+
+func writeTarGz(t *testing.T, archivePath string, entries []entry) {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	gzipWriter := gzip.NewWriter(&buf)
+	writer := tar.NewWriter(gzipWriter)
+
+	for _, e := range entries {
+		err := writer.WriteHeader(&tar.Header{
+			Name:     e.name,
+			Mode:     0o644,
+			Size:     int64(len(e.b)),
+			Typeflag: tar.TypeReg,
+		})
+		if err != nil {
+			t.Fatalf("cannot write header of %s in tar archive: %s", e.name, err.Error())
+		}
+
+		_, err = writer.Write(e.b)
+		if err != nil {
+			t.Fatalf("cannot write %s in tar archive: %s", e.name, err.Error())
+		}
+	}
+
+	err := writer.Close()
+	if err == nil {
+		err = gzipWriter.Close()
+	}
+
+	if err != nil {
+		t.Fatalf("cannot close tar archive: %s", err.Error())
+	}
+
+	err = os.WriteFile(archivePath, buf.Bytes(), 0o600)
+	if err != nil {
+		t.Fatalf("cannot write tar archive: %s", err.Error())
+	}
+}
+
+func TestNewFS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		file  string
+		write func(t *testing.T, archivePath string, entries []entry)
+	}{
+		{name: "zip", file: "repo.zip", write: writeZip},
+		{name: "tar.gz", file: "repo.tar.gz", write: writeTarGz},
+		{name: "tgz", file: "REPO.TGZ", write: writeTarGz},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			archivePath := filepath.Join(t.TempDir(), tt.file)
+			tt.write(t, archivePath, getEntries())
+
+			fsys, err := NewFS(archivePath)
+			if err != nil {
+				t.Fatalf("NewFS failed with an error: %s", err.Error())
+			}
+
+			dotGithub := FindDotGithub(fsys)
+			if dotGithub != "repo-main/.github" {
+				t.Errorf("FindDotGithub should return 'repo-main/.github', got '%s'", dotGithub)
+			}
+
+			for _, name := range []string{
+				"repo-main/.github/workflows/main.yml",
+				"repo-main/.github/actions/build/action.yml",
+				"repo-main/action.yml",
+			} {
+				_, err := fsys.ReadFile(name)
+				if err != nil {
+					t.Errorf("NewFS should read contents of %s, got error %s", name, err.Error())
+				}
+			}
+
+			for _, name := range []string{"repo-main/README.md", "repo-main/.github/large.yml"} {
+				fileInfo, err := fsys.Stat(name)
+				if err != nil {
+					t.Errorf("NewFS should add %s so that it can be stat'ed, got error %s", name, err.Error())
+
+					continue
+				}
+
+				_, err = fsys.ReadFile(name)
+				if err == nil {
+					t.Errorf("NewFS should not read contents of %s", name)
+				}
+
+				if name == "repo-main/.github/large.yml" && fileInfo.Size() != MaxFileSize+1 {
+					t.Errorf("NewFS should keep the size of %s, got %d", name, fileInfo.Size())
+				}
+			}
+
+			entries, err := fsys.ReadDir(".")
+			if err != nil || len(entries) != 1 || entries[0].Name() != "repo-main" {
+				t.Errorf("NewFS should skip entries outside of the archive root, got %v", entries)
+			}
+		})
+	}
+}
+
+func TestNewFSUnsupported(t *testing.T) {
+	t.Parallel()
+
+	archivePath := filepath.Join(t.TempDir(), "repo.rar")
+
+	err := os.WriteFile(archivePath, []byte("rar"), 0o600)
+	if err != nil {
+		t.Fatalf("cannot write archive: %s", err.Error())
+	}
+
+	_, err = NewFS(archivePath)
+	if !errors.Is(err, errUnsupportedArchive) {
+		t.Errorf("NewFS should return errUnsupportedArchive for a .rar file, got %v", err)
+	}
+}
+
+func TestFindDotGithubMissing(t *testing.T) {
+	t.Parallel()
+
+	archivePath := filepath.Join(t.TempDir(), "repo.zip")
+	writeZip(t, archivePath, []entry{{name: "repo-main/README.md", b: []byte("# Repo\n")}})
+
+	fsys, err := NewFS(archivePath)
+	if err != nil {
+		t.Fatalf("NewFS failed with an error: %s", err.Error())
+	}
+
+	dotGithub := FindDotGithub(fsys)
+	if dotGithub != "" {
+		t.Errorf("FindDotGithub should return empty string when there is no .github directory, got '%s'", dotGithub)
+	}
+}
+
+func TestGetName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		entryName string
+		name      string
+		ok        bool
+	}{
+		{entryName: "repo/.github/workflows/main.yml", name: "repo/.github/workflows/main.yml", ok: true},
+		{entryName: "/repo/action.yml", name: "repo/action.yml", ok: true},
+		{entryName: "repo\\action.yml", name: "repo/action.yml", ok: true},
+		{entryName: "repo/./sub//action.yml", name: "repo/sub/action.yml", ok: true},
+		{entryName: "repo/sub/../action.yml", name: "repo/action.yml", ok: true},
+		{entryName: "../action.yml", ok: false},
+		{entryName: "repo/../../action.yml", ok: false},
+		{entryName: "..\\action.yml", ok: false},
+		{entryName: "/", ok: false},
+		{entryName: "./", ok: false},
+	}
+
+	for _, tt := range tests {
+		name, ok := getName(tt.entryName)
+		if ok != tt.ok || (ok && name != tt.name) {
+			t.Errorf("getName(%q) should return (%q, %v), got (%q, %v)", tt.entryName, tt.name, tt.ok, name, ok)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package dotgithub
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"octo-linter/internal/step"
+)
+
+var regexpLocalAction = regexp.MustCompile(
+	`^\.\/\.github\/actions\/([a-zA-Z0-9\-_]+|[a-zA-Z0-9\-\_]+\/[a-zA-Z0-9\-_]+)$`,
+)
+
+// GetAffectedFiles returns paths of actions and workflows that are on the given list of changed paths, together with
+// actions and workflows calling a changed local action, directly or through other local actions.
+func (d *DotGithub) GetAffectedFiles(changedPaths []string) []string {
+	changed := make(map[string]struct{}, len(changedPaths))
+	for _, path := range changedPaths {
+		changed[getRealPath(path)] = struct{}{}
+	}
+
+	affectedActions := map[string]struct{}{}
+
+	for name, action := range d.Actions {
+		if _, ok := changed[getRealPath(action.Path)]; ok {
+			affectedActions[name] = struct{}{}
+		}
+	}
+
+	// composite actions may call other local actions so repeat until no new action is found
+	for {
+		numAffected := len(affectedActions)
+
+		for name, action := range d.Actions {
+			if _, ok := affectedActions[name]; ok || action.Runs == nil {
+				continue
+			}
+
+			if callsAnyLocalAction(action.Runs.Steps, affectedActions) {
+				affectedActions[name] = struct{}{}
+			}
+		}
+
+		if len(affectedActions) == numAffected {
+			break
+		}
+	}
+
+	files := []string{}
+	for name := range affectedActions {
+		files = append(files, d.Actions[name].Path)
+	}
+
+	for _, workflow := range d.Workflows {
+		_, isChanged := changed[getRealPath(workflow.Path)]
+		if isChanged {
+			files = append(files, workflow.Path)
+
+			continue
+		}
+
+		for _, job := range workflow.Jobs {
+			if callsAnyLocalAction(job.Steps, affectedActions) {
+				files = append(files, workflow.Path)
+
+				break
+			}
+		}
+	}
+
+	return files
+}
+
+func callsAnyLocalAction(steps []*step.Step, actionNames map[string]struct{}) bool {
+	for _, stepInstance := range steps {
+		name, ok := GetLocalActionName(stepInstance.Uses)
+		if !ok {
+			continue
+		}
+
+		if _, ok := actionNames[name]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetLocalActionName returns the name under which the local action called with 'uses' is stored in Actions. The
+// repository-root action, called with './', is stored under RootActionName.
+func GetLocalActionName(uses string) (string, bool) {
+	if uses == "./" || uses == "." {
+		return RootActionName, true
+	}
+
+	if !regexpLocalAction.MatchString(uses) {
+		return "", false
+	}
+
+	return strings.ReplaceAll(uses, "./.github/actions/", ""), true
+}
+
+// getRealPath returns an absolute path with symbolic links resolved so that paths returned by external tools can be
+// compared with the ones of loaded files.
+func getRealPath(path string) string {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+
+	realPath, err := filepath.EvalSymlinks(absPath)
+	if err != nil {
+		return absPath
+	}
+
+	return realPath
+}
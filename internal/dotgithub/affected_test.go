@@ -0,0 +1,82 @@
+package dotgithub
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"octo-linter/internal/memfs"
+)
+
+func TestGetAffectedFiles(t *testing.T) {
+	t.Parallel()
+
+	fsys := memfs.New()
+
+	for path, content := range map[string]string{
+		"action.yml": "name: Root\nruns:\n  using: composite\n  steps:\n    - run: echo\n      shell: bash\n",
+		".github/actions/build/action.yml": "name: Build\nruns:\n  using: composite\n  steps:\n" +
+			"    - uses: ./.github/actions/setup\n",
+		".github/actions/setup/action.yml": "name: Setup\nruns:\n  using: composite\n  steps:\n    - run: echo\n      shell: bash\n",
+		".github/actions/lint/action.yml":  "name: Lint\nruns:\n  using: node20\n  main: index.js\n",
+		".github/workflows/build.yml": "on: push\njobs:\n  main:\n    runs-on: ubuntu-latest\n    steps:\n" +
+			"      - uses: ./.github/actions/build\n",
+		".github/workflows/release.yml": "on: push\njobs:\n  main:\n    runs-on: ubuntu-latest\n    steps:\n" +
+			"      - uses: actions/checkout@v4\n      - uses: ./\n",
+		".github/workflows/lint.yml": "on: push\njobs:\n  main:\n    runs-on: ubuntu-latest\n    steps:\n" +
+			"      - uses: ./.github/actions/lint\n",
+	} {
+		_ = fsys.AddFile(path, []byte(content))
+	}
+
+	d := &DotGithub{FS: fsys, RootActionPath: "."}
+
+	err := d.ReadDir(context.Background(), ".github", nil, nil)
+	if err != nil {
+		t.Fatalf("ReadDir failed with an error: %s", err.Error())
+	}
+
+	for _, tt := range []struct {
+		changed  []string
+		expected []string
+	}{
+		{changed: nil, expected: []string{}},
+		{changed: []string{"README.md"}, expected: []string{}},
+		{
+			changed:  []string{".github/workflows/lint.yml"},
+			expected: []string{".github/workflows/lint.yml"},
+		},
+		{
+			changed:  []string{".github/actions/lint/action.yml"},
+			expected: []string{".github/actions/lint/action.yml", ".github/workflows/lint.yml"},
+		},
+		{
+			changed: []string{".github/actions/setup/action.yml"},
+			expected: []string{
+				".github/actions/build/action.yml",
+				".github/actions/setup/action.yml",
+				".github/workflows/build.yml",
+			},
+		},
+		{
+			changed:  []string{"action.yml"},
+			expected: []string{".github/workflows/release.yml", "action.yml"},
+		},
+		{
+			changed:  []string{getRealPath(".github/workflows/build.yml")},
+			expected: []string{".github/workflows/build.yml"},
+		},
+	} {
+		result := d.GetAffectedFiles(tt.changed)
+		slices.Sort(result)
+
+		for i := range result {
+			result[i] = filepath.ToSlash(result[i])
+		}
+
+		if !slices.Equal(result, tt.expected) {
+			t.Errorf("GetAffectedFiles(%v) should return %v, got %v", tt.changed, tt.expected, result)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package dotgithub
+
+import (
+	"regexp"
+	"strings"
+
+	"octo-linter/internal/action"
+	"octo-linter/internal/step"
+	"octo-linter/internal/workflow"
+)
+
+var regexpLocalWorkflow = regexp.MustCompile(`^\.\/\.github\/workflows\/([a-zA-Z0-9\-_\.]+\.ya?ml)$`)
+
+// GetDependencies returns contents of other files that rules may look at when linting the given file, keyed by a
+// unique name. These are local actions called by it, directly or through other local actions, external actions and
+// local reusable workflows. For an action, existence of files referenced in 'runs' is included as well.
+func (d *DotGithub) GetDependencies(file File) map[string][]byte {
+	deps := map[string][]byte{}
+
+	switch fileInstance := file.(type) {
+	case *action.Action:
+		if fileInstance.Runs != nil {
+			d.addStepsDependencies(deps, fileInstance.Runs.Steps)
+			d.addReferencedFilesDependencies(deps, fileInstance)
+		}
+	case *workflow.Workflow:
+		for _, job := range fileInstance.Jobs {
+			d.addStepsDependencies(deps, job.Steps)
+
+			matches := regexpLocalWorkflow.FindStringSubmatch(job.Uses)
+			if len(matches) == 2 && d.Workflows[matches[1]] != nil {
+				deps["workflow:"+matches[1]] = d.Workflows[matches[1]].Raw
+			}
+		}
+	}
+
+	return deps
+}
+
+func (d *DotGithub) addStepsDependencies(deps map[string][]byte, steps []*step.Step) {
+	for _, stepInstance := range steps {
+		if regexpExternalAction.MatchString(stepInstance.Uses) {
+			externalAction := d.ExternalActions[stepInstance.Uses]
+			if externalAction != nil {
+				deps["external:"+stepInstance.Uses] = externalAction.Raw
+			}
+
+			continue
+		}
+
+		name, ok := GetLocalActionName(stepInstance.Uses)
+		if !ok {
+			continue
+		}
+
+		key := "action:" + name
+		if _, visited := deps[key]; visited {
+			continue
+		}
+
+		localAction := d.Actions[name]
+		if localAction == nil {
+			deps[key] = nil
+
+			continue
+		}
+
+		deps[key] = localAction.Raw
+
+		if localAction.Runs != nil {
+			d.addStepsDependencies(deps, localAction.Runs.Steps)
+		}
+	}
+}
+
+func (d *DotGithub) addReferencedFilesDependencies(deps map[string][]byte, actionInstance *action.Action) {
+	runs := actionInstance.Runs
+
+	for _, path := range []string{runs.Main, runs.Pre, runs.Post, runs.Image} {
+		if path == "" || strings.HasPrefix(path, action.DockerImagePrefix) {
+			continue
+		}
+
+		exists := []byte{0}
+		if d.IsActionFileExist(actionInstance, path) {
+			exists = []byte{1}
+		}
+
+		deps["file:"+path] = exists
+	}
+}
@@ -6,14 +6,19 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log/slog"
+	"maps"
 	"net/http"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	"octo-linter/internal/action"
+	"octo-linter/internal/memfs"
+	"octo-linter/internal/profile"
 	"octo-linter/internal/workflow"
 )
 
@@ -24,6 +29,23 @@ type DotGithub struct {
 	Workflows       map[string]*workflow.Workflow
 	Vars            map[string]bool
 	Secrets         map[string]bool
+	// ParseErrors contains files, keyed by path, that could not be parsed. They remain in Actions and Workflows but
+	// are not linted.
+	ParseErrors map[string]*ParseError
+	// Overlay contains file contents, keyed by absolute path, that are used instead of the files on disk, eg. a
+	// workflow read from standard input. Actions and workflows in the overlay that do not exist on disk are added.
+	Overlay map[string][]byte
+	// RootActionPath is a directory, usually the repository root, that contains an action published outside the
+	// 'actions' directory. When set, the action is loaded into Actions under RootActionName.
+	RootActionPath string
+	// NumParseWorkers limits the number of files that are read and parsed concurrently. Defaults to the number of
+	// CPUs.
+	NumParseWorkers int
+	// FS, when set, is used to read actions and workflows instead of the local disk, eg. to read them from a git
+	// revision. Paths are then slash-separated and relative to its root. Override paths are still read from disk.
+	FS fs.FS
+	// Profiler, when set, measures loading the directory and downloading each external action.
+	Profiler *profile.Profiler
 }
 
 const (
@@ -32,6 +54,9 @@ const (
 	// NumExternalActionPathPartsNoSubdir defines the number of segments in a 'uses' path split by '/' when the action
 	// is not in a subdirectory.
 	NumExternalActionPathPartsNoSubdir = 2
+	// RootActionName is a reserved key in Actions under which the repository-root action is stored. It contains
+	// characters that are not allowed in a local action path so it cannot clash with an action directory.
+	RootActionName = "(root)"
 )
 
 var (
@@ -62,8 +87,11 @@ func (d *DotGithub) ReadDir(
 	overridePaths map[string]string,
 	overrideOutputs map[string][]*regexp.Regexp,
 ) error {
+	defer d.Profiler.Start(profile.CategoryLoad, "load", path, 0)()
+
 	d.Actions = make(map[string]*action.Action)
 	d.Workflows = make(map[string]*workflow.Workflow)
+	d.ParseErrors = make(map[string]*ParseError)
 
 	err := d.getActionsFromDir(path, overridePaths, overrideOutputs)
 	if err != nil {
@@ -88,6 +116,31 @@ func (d *DotGithub) ReadDir(
 	return nil
 }
 
+// NewFromFiles returns a DotGithub with actions and workflows read from a map of file contents, keyed by
+// slash-separated paths relative to the .github directory, eg. 'workflows/main.yml' or 'actions/build/action.yml'.
+// It is meant for tests and for embedding the linter in other tools.
+func NewFromFiles(ctx context.Context, files map[string][]byte) (*DotGithub, error) {
+	fsys := memfs.New()
+
+	for path, b := range files {
+		err := fsys.AddFile(path, b)
+		if err != nil {
+			return nil, fmt.Errorf("error adding file %s: %w", path, err)
+		}
+	}
+
+	dotGithub := &DotGithub{
+		FS: fsys,
+	}
+
+	err := dotGithub.ReadDir(ctx, ".", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return dotGithub, nil
+}
+
 // ReadVars reads a file with GitHub Actions variables, parsing each line into the struct as a variable.
 func (d *DotGithub) ReadVars(path string) error {
 	if path == "" {
@@ -163,6 +216,8 @@ func (d *DotGithub) DownloadExternalAction(ctx context.Context, path string, ove
 		return nil
 	}
 
+	defer d.Profiler.Start(profile.CategoryDownload, path, "", 0)()
+
 	repoVersion := strings.Split(path, "@")
 	ownerRepoDir := strings.SplitN(repoVersion[0], "/", NumExternalActionPathParts)
 
@@ -211,7 +266,7 @@ func (d *DotGithub) DownloadExternalAction(ctx context.Context, path string, ove
 
 	d.ExternalActions[path] = actionInstance
 
-	err = d.ExternalActions[path].Unmarshal(true)
+	err = d.ExternalActions[path].Unmarshal()
 	if err != nil {
 		return fmt.Errorf("error unmarshaling external action: %w", err)
 	}
@@ -219,6 +274,23 @@ func (d *DotGithub) DownloadExternalAction(ctx context.Context, path string, ove
 	return nil
 }
 
+// IsActionFileExist checks whether a file, given as a path relative to the action directory, exists next to the
+// action's YAML file.
+func (d *DotGithub) IsActionFileExist(actionInstance *action.Action, path string) bool {
+	if actionInstance == nil || actionInstance.DirName == "" {
+		return false
+	}
+
+	files := d.files()
+
+	fileInfo, err := files.Stat(files.Join(files.Dir(actionInstance.Path), files.Clean(path)))
+	if err != nil {
+		return false
+	}
+
+	return fileInfo.Mode().IsRegular()
+}
+
 // IsVarExist checks whether the variable has been loaded from the variables file.
 func (d *DotGithub) IsVarExist(name string) bool {
 	_, ok := d.Vars[name]
@@ -234,19 +306,20 @@ func (d *DotGithub) IsSecretExist(name string) bool {
 }
 
 func (d *DotGithub) getActionsFromDir(path string, overridePaths map[string]string, overrideOutputs map[string][]*regexp.Regexp) error {
-	dirActions := filepath.Join(path, "actions")
+	files := d.files()
+	dirActions := files.Join(path, "actions")
 
-	entries, err := os.ReadDir(dirActions)
+	entries, err := files.ReadDir(dirActions)
 	if err != nil {
-		if !os.IsNotExist(err) {
+		if !errors.Is(err, fs.ErrNotExist) {
 			return fmt.Errorf("error reading actions directory: %w", err)
 		}
 	}
 
 	for _, entry := range entries {
-		dirAction := filepath.Join(dirActions, entry.Name())
+		dirAction := files.Join(dirActions, entry.Name())
 
-		ymlAction, err := getActionYAMLFromPath(dirAction)
+		ymlAction, err := getActionYAMLFromPath(files, dirAction)
 		if err != nil {
 			return err
 		}
@@ -270,6 +343,13 @@ func (d *DotGithub) getActionsFromDir(path string, overridePaths map[string]stri
 		d.Actions[entry.Name()] = actionInstance
 	}
 
+	d.addActionsFromOverlay(dirActions, overrideOutputs)
+
+	err = d.getRootAction()
+	if err != nil {
+		return err
+	}
+
 	if len(overridePaths) == 0 {
 		return nil
 	}
@@ -279,7 +359,7 @@ func (d *DotGithub) getActionsFromDir(path string, overridePaths map[string]stri
 	}
 
 	for actionPath, localPath := range overridePaths {
-		ymlAction, err := getActionYAMLFromPath(localPath)
+		ymlAction, err := getActionYAMLFromPath(diskFileSystem(), localPath)
 		if err != nil {
 			return err
 		}
@@ -306,12 +386,44 @@ func (d *DotGithub) getActionsFromDir(path string, overridePaths map[string]stri
 	return nil
 }
 
+func (d *DotGithub) getRootAction() error {
+	if d.RootActionPath == "" {
+		return nil
+	}
+
+	ymlAction, err := getActionYAMLFromPath(d.files(), d.RootActionPath)
+	if err != nil {
+		return fmt.Errorf("error getting root action: %w", err)
+	}
+
+	if ymlAction == "" {
+		return nil
+	}
+
+	if d.Actions[RootActionName] != nil {
+		slog.Warn(
+			"action directory uses a name reserved for the root action and it is ignored",
+			slog.String("name", RootActionName),
+		)
+	}
+
+	d.Actions[RootActionName] = &action.Action{
+		Path:    ymlAction,
+		DirName: RootActionName,
+	}
+
+	return nil
+}
+
 func (d *DotGithub) getWorkflowsFromDir(path string) error {
-	dirWorkflows := filepath.Join(path, "workflows")
+	files := d.files()
+	dirWorkflows := files.Join(path, "workflows")
 
-	entries, err := os.ReadDir(dirWorkflows)
+	entries, err := files.ReadDir(dirWorkflows)
 	if err != nil {
-		return fmt.Errorf("error reading workflows directory %s: %w", dirWorkflows, err)
+		if !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("error reading workflows directory %s: %w", dirWorkflows, err)
+		}
 	}
 
 	for _, entry := range entries {
@@ -320,11 +432,11 @@ func (d *DotGithub) getWorkflowsFromDir(path string) error {
 			continue
 		}
 
-		ymlWorkflow := filepath.Join(dirWorkflows, entry.Name())
+		ymlWorkflow := files.Join(dirWorkflows, entry.Name())
 
-		fileInfo, err := os.Stat(ymlWorkflow)
+		fileInfo, err := files.Stat(ymlWorkflow)
 		if err != nil {
-			return fmt.Errorf("error getting os.Stat on %s: %w", ymlWorkflow, err)
+			return fmt.Errorf("error getting stat on %s: %w", ymlWorkflow, err)
 		}
 
 		if !fileInfo.Mode().IsRegular() {
@@ -336,14 +448,21 @@ func (d *DotGithub) getWorkflowsFromDir(path string) error {
 		}
 	}
 
+	d.addWorkflowsFromOverlay(dirWorkflows)
+
 	return nil
 }
 
 func (d *DotGithub) processActions(ctx context.Context, overrideOutputs map[string][]*regexp.Regexp) error {
 	// get contents from already existing external actions that are overridden by local actions
 	var err error
-	for path := range d.ExternalActions {
-		err = d.ExternalActions[path].Unmarshal(false)
+	for path, externalAction := range d.ExternalActions {
+		externalAction.Raw, err = readFile(diskFileSystem(), externalAction.Path)
+		if err != nil {
+			return fmt.Errorf("error reading external action overridden by a local file %s: %w", path, err)
+		}
+
+		err = externalAction.Unmarshal()
 		if err != nil {
 			return fmt.Errorf(
 				"error unmarshaling external action overridden by a local file %s: %w",
@@ -353,11 +472,32 @@ func (d *DotGithub) processActions(ctx context.Context, overrideOutputs map[stri
 		}
 	}
 
+	names := slices.Sorted(maps.Keys(d.Actions))
+	jobs := make([]parseJob, len(names))
+
+	for i, name := range names {
+		action := d.Actions[name]
+		jobs[i] = parseJob{path: action.Path, file: action, setRaw: func(b []byte) { action.Raw = b }}
+	}
+
+	parseErrs, err := d.parseFiles(jobs)
+	if err != nil {
+		return fmt.Errorf("error reading action: %w", err)
+	}
+
 	// download all external actions used in actions' steps
-	for _, action := range d.Actions {
-		err := action.Unmarshal(false)
-		if err != nil {
-			return fmt.Errorf("error unmarshaling action: %w", err)
+	for i, name := range names {
+		action := d.Actions[name]
+
+		if parseErrs[i] != nil {
+			parseErr := newParseError(action.Path, action.DirName, action.GetType(), parseErrs[i])
+			if parseErr == nil {
+				return fmt.Errorf("error unmarshaling action: %w", parseErrs[i])
+			}
+
+			d.addParseError(parseErr)
+
+			continue
 		}
 
 		if action.Runs == nil || len(action.Runs.Steps) == 0 {
@@ -386,11 +526,32 @@ func (d *DotGithub) processActions(ctx context.Context, overrideOutputs map[stri
 }
 
 func (d *DotGithub) processWorkflows(ctx context.Context, overrideOutputs map[string][]*regexp.Regexp) error {
-	// download all external actions used in actions' steps
-	for _, workflow := range d.Workflows {
-		err := workflow.Unmarshal(false)
-		if err != nil {
-			return fmt.Errorf("error unmarshaling workflow: %w", err)
+	names := slices.Sorted(maps.Keys(d.Workflows))
+	jobs := make([]parseJob, len(names))
+
+	for i, name := range names {
+		workflow := d.Workflows[name]
+		jobs[i] = parseJob{path: workflow.Path, file: workflow, setRaw: func(b []byte) { workflow.Raw = b }}
+	}
+
+	parseErrs, err := d.parseFiles(jobs)
+	if err != nil {
+		return fmt.Errorf("error reading workflow: %w", err)
+	}
+
+	// download all external actions used in workflows' steps
+	for i, name := range names {
+		workflow := d.Workflows[name]
+
+		if parseErrs[i] != nil {
+			parseErr := newParseError(workflow.Path, workflow.DisplayName, workflow.GetType(), parseErrs[i])
+			if parseErr == nil {
+				return fmt.Errorf("error unmarshaling workflow: %w", parseErrs[i])
+			}
+
+			d.addParseError(parseErr)
+
+			continue
 		}
 
 		if len(workflow.Jobs) == 0 {
@@ -481,11 +642,11 @@ func (d *DotGithub) getActionHTTPResponse(
 	return resp, nil
 }
 
-func getActionYAMLFromPath(path string) (string, error) {
+func getActionYAMLFromPath(files fileSystem, path string) (string, error) {
 	// only directories
-	fileInfo, err := os.Stat(path)
+	fileInfo, err := files.Stat(path)
 	if err != nil {
-		return "", fmt.Errorf("error getting os.Stat on %s: %w", path, err)
+		return "", fmt.Errorf("error getting stat on %s: %w", path, err)
 	}
 
 	if !fileInfo.IsDir() {
@@ -493,28 +654,17 @@ func getActionYAMLFromPath(path string) (string, error) {
 	}
 
 	// search for action.yml or action.yaml file
-	ymlAction := filepath.Join(path, "action.yml")
-	_, err = os.Stat(ymlAction)
-
-	ymlNotFound := os.IsNotExist(err)
-	if err != nil && !ymlNotFound {
-		return "", fmt.Errorf("error getting os.Stat on %s: %w", ymlAction, err)
-	}
-
-	if !ymlNotFound {
-		return ymlAction, nil
-	}
-
-	yamlAction := filepath.Join(path, "action.yaml")
-	_, err = os.Stat(yamlAction)
+	for _, fileName := range []string{"action.yml", "action.yaml"} {
+		ymlAction := files.Join(path, fileName)
 
-	yamlNotFound := os.IsNotExist(err)
-	if err != nil && !yamlNotFound {
-		return "", fmt.Errorf("error getting os.Stat on %s: %w", yamlAction, err)
-	}
+		_, err = files.Stat(ymlAction)
+		if err == nil {
+			return ymlAction, nil
+		}
 
-	if !yamlNotFound {
-		return yamlAction, nil
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("error getting stat on %s: %w", ymlAction, err)
+		}
 	}
 
 	return "", nil
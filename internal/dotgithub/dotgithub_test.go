@@ -0,0 +1,113 @@
+package dotgithub
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"octo-linter/internal/memfs"
+)
+
+func TestNewFromFiles(t *testing.T) {
+	t.Parallel()
+
+	d, err := NewFromFiles(context.Background(), map[string][]byte{
+		"actions/build/action.yml":    []byte("name: Build\nruns:\n  using: node20\n  main: dist/index.js\n"),
+		"actions/build/dist/index.js": []byte("console.log('build')\n"),
+		"workflows/main.yml":          []byte("name: Main\non: push\njobs:\n  main:\n    runs-on: ubuntu-latest\n"),
+		"workflows/broken.yml":        []byte("name: Broken\njobs:\n  main: runs-on: ubuntu-latest\n"),
+	})
+	if err != nil {
+		t.Fatalf("NewFromFiles failed with an error: %s", err.Error())
+	}
+
+	actionInstance := d.GetAction("build")
+	if actionInstance == nil || actionInstance.Name != "Build" {
+		t.Fatalf("NewFromFiles should load action 'build'")
+	}
+
+	if !d.IsActionFileExist(actionInstance, "dist/index.js") || d.IsActionFileExist(actionInstance, "missing.js") {
+		t.Errorf("IsActionFileExist should check files in the action directory of the in-memory tree")
+	}
+
+	if d.Workflows["main.yml"] == nil || d.Workflows["main.yml"].Name != "Main" {
+		t.Errorf("NewFromFiles should load workflow 'main.yml'")
+	}
+
+	parseErr := d.GetParseError("workflows/broken.yml")
+	if parseErr == nil || parseErr.Line != 3 {
+		t.Errorf("NewFromFiles should record a parse error on line 3 of 'broken.yml', got %v", parseErr)
+	}
+}
+
+func TestNewFromFilesActionsOnly(t *testing.T) {
+	t.Parallel()
+
+	d, err := NewFromFiles(context.Background(), map[string][]byte{
+		"actions/build/action.yml": []byte("name: Build\nruns:\n  using: composite\n  steps: []\n"),
+	})
+	if err != nil {
+		t.Fatalf("NewFromFiles should accept a tree without a workflows directory, got: %s", err.Error())
+	}
+
+	if d.GetAction("build") == nil || len(d.Workflows) != 0 {
+		t.Errorf("NewFromFiles should load action 'build' and no workflows")
+	}
+}
+
+func TestNewFromFilesInvalidPath(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewFromFiles(context.Background(), map[string][]byte{
+		"../workflows/main.yml": []byte("name: Main\n"),
+	})
+	if err == nil {
+		t.Errorf("NewFromFiles should return an error when a path is not valid")
+	}
+}
+
+func TestReadDirParallelMatchesSequential(t *testing.T) {
+	t.Parallel()
+
+	fsys := memfs.New()
+
+	for i := range 50 {
+		workflow := fmt.Sprintf("name: Workflow %d\non: push\njobs:\n  main:\n    runs-on: ubuntu-latest\n", i)
+		if i%7 == 0 {
+			workflow = fmt.Sprintf("name: Workflow %d\njobs:\n  main: runs-on: ubuntu-latest\n", i)
+		}
+
+		_ = fsys.AddFile(fmt.Sprintf("workflows/workflow-%d.yml", i), []byte(workflow))
+		_ = fsys.AddFile(fmt.Sprintf("actions/action-%d/action.yml", i), fmt.Appendf(nil, "name: Action %d\n", i))
+	}
+
+	sequential := &DotGithub{FS: fsys, NumParseWorkers: 1}
+	parallel := &DotGithub{FS: fsys, NumParseWorkers: 8}
+
+	for _, d := range []*DotGithub{sequential, parallel} {
+		err := d.ReadDir(context.Background(), ".", nil, nil)
+		if err != nil {
+			t.Fatalf("ReadDir failed with an error: %s", err.Error())
+		}
+	}
+
+	if len(parallel.ParseErrors) != len(sequential.ParseErrors) || len(parallel.ParseErrors) != 8 {
+		t.Errorf(
+			"ReadDir should return the same 8 parse errors, got %d sequentially and %d in parallel",
+			len(sequential.ParseErrors),
+			len(parallel.ParseErrors),
+		)
+	}
+
+	for name, workflow := range sequential.Workflows {
+		if parallel.Workflows[name].Name != workflow.Name {
+			t.Errorf("ReadDir should parse workflow %s the same way in parallel", name)
+		}
+	}
+
+	for name, action := range sequential.Actions {
+		if parallel.Actions[name].Name != action.Name {
+			t.Errorf("ReadDir should parse action %s the same way in parallel", name)
+		}
+	}
+}
@@ -2,6 +2,6 @@ package dotgithub
 
 // File represents both GitHub Actions action and workflow.
 type File interface {
-	Unmarshal(fromRaw bool) error
+	Unmarshal() error
 	GetType() int
 }
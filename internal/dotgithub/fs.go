@@ -0,0 +1,121 @@
+package dotgithub
+
+import (
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// fileSystem reads actions and workflows from an fs.FS. Files on the local disk are read through os.DirFS, and their
+// paths remain paths of the operating system, relative to the working directory or absolute, so that they can be
+// reported and compared with paths given by the user.
+type fileSystem struct {
+	fsys fs.FS
+	// isDisk is set when the files are read from the local disk.
+	isDisk bool
+}
+
+// diskFileSystem returns a fileSystem reading files from the local disk.
+func diskFileSystem() fileSystem {
+	return fileSystem{isDisk: true}
+}
+
+func (f fileSystem) ReadDir(name string) ([]fs.DirEntry, error) {
+	fsys, fsPath := f.resolve(name)
+
+	return fs.ReadDir(fsys, fsPath)
+}
+
+func (f fileSystem) ReadFile(name string) ([]byte, error) {
+	fsys, fsPath := f.resolve(name)
+
+	return fs.ReadFile(fsys, fsPath)
+}
+
+func (f fileSystem) Stat(name string) (fs.FileInfo, error) {
+	fsys, fsPath := f.resolve(name)
+
+	return fs.Stat(fsys, fsPath)
+}
+
+func (f fileSystem) Join(elem ...string) string {
+	if f.isDisk {
+		return filepath.Join(elem...)
+	}
+
+	return path.Join(elem...)
+}
+
+func (f fileSystem) Dir(name string) string {
+	if f.isDisk {
+		return filepath.Dir(name)
+	}
+
+	return path.Dir(name)
+}
+
+func (f fileSystem) Clean(name string) string {
+	if f.isDisk {
+		return filepath.Clean(name)
+	}
+
+	return path.Clean(name)
+}
+
+// resolve returns the fs.FS and the slash-separated path in it that the named file is opened with. A path on the local
+// disk is opened with os.DirFS of the root of its volume.
+func (f fileSystem) resolve(name string) (fs.FS, string) {
+	if !f.isDisk {
+		return f.fsys, name
+	}
+
+	absPath, err := filepath.Abs(name)
+	if err != nil {
+		return os.DirFS("."), filepath.ToSlash(name)
+	}
+
+	volume := filepath.VolumeName(absPath)
+
+	fsPath := strings.TrimPrefix(filepath.ToSlash(absPath[len(volume):]), "/")
+	if fsPath == "" {
+		fsPath = "."
+	}
+
+	return os.DirFS(volume + string(filepath.Separator)), fsPath
+}
+
+// files returns the file system that actions and workflows are read from.
+func (d *DotGithub) files() fileSystem {
+	if d.FS != nil {
+		return fileSystem{fsys: d.FS}
+	}
+
+	return diskFileSystem()
+}
+
+// readFile returns contents of an action or a workflow file, taking the overlay into account.
+func (d *DotGithub) readFile(path string) ([]byte, error) {
+	if b, ok := d.getOverlay(path); ok {
+		return b, nil
+	}
+
+	return readFile(d.files(), path)
+}
+
+func readFile(files fileSystem, path string) ([]byte, error) {
+	slog.Debug(
+		"reading file",
+		slog.String("path", path),
+	)
+
+	b, err := files.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read file %s: %w", path, err)
+	}
+
+	return b, nil
+}
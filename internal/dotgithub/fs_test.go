@@ -0,0 +1,51 @@
+package dotgithub
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDiskFileSystem(t *testing.T) {
+	t.Parallel()
+
+	files := diskFileSystem()
+
+	absPath, err := filepath.Abs(filepath.Join("..", "..", "tests", "root-action"))
+	if err != nil {
+		t.Fatalf("cannot get absolute path: %s", err.Error())
+	}
+
+	expected, err := os.ReadFile(filepath.Join(absPath, "action.yml"))
+	if err != nil {
+		t.Fatalf("cannot read file: %s", err.Error())
+	}
+
+	for _, dir := range []string{filepath.Join("..", "..", "tests", "root-action"), absPath} {
+		b, err := files.ReadFile(files.Join(dir, "action.yml"))
+		if err != nil || string(b) != string(expected) {
+			t.Errorf("ReadFile should read action.yml in %s, got error %v", dir, err)
+		}
+
+		entries, err := files.ReadDir(dir)
+		if err != nil || len(entries) == 0 {
+			t.Errorf("ReadDir should list files in %s, got error %v", dir, err)
+		}
+
+		fileInfo, err := files.Stat(dir)
+		if err != nil || !fileInfo.IsDir() {
+			t.Errorf("Stat should return a directory for %s, got error %v", dir, err)
+		}
+	}
+
+	_, err = files.Stat(files.Join(absPath, "missing.yml"))
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Stat should return fs.ErrNotExist for a missing file, got %v", err)
+	}
+
+	if fileInfo, err := files.Stat(string(filepath.Separator)); err != nil || !fileInfo.IsDir() {
+		t.Errorf("Stat should return the root directory, got error %v", err)
+	}
+}
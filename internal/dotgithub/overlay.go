@@ -0,0 +1,81 @@
+package dotgithub
+
+import (
+	"path/filepath"
+	"regexp"
+
+	"octo-linter/internal/action"
+	"octo-linter/internal/workflow"
+)
+
+// getOverlay returns contents of the file from the overlay.
+func (d *DotGithub) getOverlay(path string) ([]byte, bool) {
+	if len(d.Overlay) == 0 {
+		return nil, false
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, false
+	}
+
+	b, ok := d.Overlay[absPath]
+
+	return b, ok
+}
+
+// addActionsFromOverlay adds actions from the overlay that are placed in the actions directory but do not exist on
+// disk.
+func (d *DotGithub) addActionsFromOverlay(dirActions string, overrideOutputs map[string][]*regexp.Regexp) {
+	absDirActions, err := filepath.Abs(dirActions)
+	if err != nil {
+		return
+	}
+
+	for overlayPath := range d.Overlay {
+		fileName := filepath.Base(overlayPath)
+		if fileName != "action.yml" && fileName != "action.yaml" {
+			continue
+		}
+
+		dirAction := filepath.Dir(overlayPath)
+		if filepath.Dir(dirAction) != absDirActions {
+			continue
+		}
+
+		dirName := filepath.Base(dirAction)
+		if d.Actions[dirName] != nil {
+			continue
+		}
+
+		d.Actions[dirName] = &action.Action{
+			Path:           filepath.Join(dirActions, dirName, fileName),
+			DirName:        dirName,
+			DynamicOutputs: overrideOutputs[dirName],
+		}
+	}
+}
+
+// addWorkflowsFromOverlay adds workflows from the overlay that are placed in the workflows directory but do not exist
+// on disk.
+func (d *DotGithub) addWorkflowsFromOverlay(dirWorkflows string) {
+	absDirWorkflows, err := filepath.Abs(dirWorkflows)
+	if err != nil {
+		return
+	}
+
+	for overlayPath := range d.Overlay {
+		fileName := filepath.Base(overlayPath)
+		if filepath.Dir(overlayPath) != absDirWorkflows || !filenameRegex.MatchString(fileName) {
+			continue
+		}
+
+		if d.Workflows[fileName] != nil {
+			continue
+		}
+
+		d.Workflows[fileName] = &workflow.Workflow{
+			Path: filepath.Join(dirWorkflows, fileName),
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package dotgithub
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOverlay(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), ".github")
+
+	for path, content := range map[string]string{
+		"workflows/main.yml":       "name: Main\non: push\njobs:\n  main:\n    runs-on: ubuntu-latest\n",
+		"actions/build/action.yml": "name: Build\nruns:\n  using: node20\n  main: index.js\n",
+	} {
+		err := os.MkdirAll(filepath.Join(dir, filepath.Dir(path)), 0o750)
+		if err != nil {
+			t.Fatalf("cannot create directory: %s", err.Error())
+		}
+
+		err = os.WriteFile(filepath.Join(dir, path), []byte(content), 0o600)
+		if err != nil {
+			t.Fatalf("cannot write file: %s", err.Error())
+		}
+	}
+
+	d := &DotGithub{
+		Overlay: map[string][]byte{
+			// replaces a file on disk
+			filepath.Join(dir, "workflows", "main.yml"): []byte("name: Overlay main\non: push\njobs: {}\n"),
+			// adds files that do not exist on disk
+			filepath.Join(dir, "workflows", "new.yaml"):           []byte("name: New\non: push\njobs: {}\n"),
+			filepath.Join(dir, "actions", "deploy", "action.yml"): []byte("name: Deploy\n"),
+			// is ignored as it is not placed in the workflows or the actions directory
+			filepath.Join(dir, "workflows", "nested", "other.yml"): []byte("name: Other\n"),
+			filepath.Join(dir, "workflows", "README.md"):           []byte("# Workflows\n"),
+			filepath.Join(dir, "actions", "a", "b", "action.yml"):  []byte("name: Nested\n"),
+		},
+	}
+
+	err := d.ReadDir(context.Background(), dir, nil, nil)
+	if err != nil {
+		t.Fatalf("ReadDir failed with an error: %s", err.Error())
+	}
+
+	for name, expected := range map[string]string{"main.yml": "Overlay main", "new.yaml": "New"} {
+		if d.Workflows[name] == nil || d.Workflows[name].Name != expected {
+			t.Errorf("ReadDir should load workflow '%s' with name '%s' from the overlay", name, expected)
+		}
+	}
+
+	for name, expected := range map[string]string{"build": "Build", "deploy": "Deploy"} {
+		if d.Actions[name] == nil || d.Actions[name].Name != expected {
+			t.Errorf("ReadDir should load action '%s' with name '%s'", name, expected)
+		}
+	}
+
+	if len(d.Workflows) != 2 || len(d.Actions) != 2 {
+		t.Errorf(
+			"ReadDir should ignore overlay files outside the workflows and actions directories, got %d workflows and %d actions",
+			len(d.Workflows),
+			len(d.Actions),
+		)
+	}
+
+	if d.Actions["deploy"].Path != filepath.Join(dir, "actions", "deploy", "action.yml") {
+		t.Errorf("ReadDir should set the path of an action from the overlay, got %s", d.Actions["deploy"].Path)
+	}
+}
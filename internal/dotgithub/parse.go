@@ -0,0 +1,64 @@
+package dotgithub
+
+import (
+	"runtime"
+	"sync"
+)
+
+// parseJob is an action or a workflow file to be read and unmarshaled.
+type parseJob struct {
+	path   string
+	file   File
+	setRaw func(b []byte)
+}
+
+// parseFiles reads and unmarshals files concurrently, using at most NumParseWorkers goroutines. It returns
+// unmarshaling errors, indexed like jobs, or the error of the first job whose file could not be read. Jobs only
+// modify their own file so the DotGithub maps are not modified concurrently.
+func (d *DotGithub) parseFiles(jobs []parseJob) ([]error, error) {
+	readErrs := make([]error, len(jobs))
+	parseErrs := make([]error, len(jobs))
+
+	numWorkers := d.NumParseWorkers
+	if numWorkers <= 0 {
+		numWorkers = runtime.NumCPU()
+	}
+
+	numWorkers = min(numWorkers, len(jobs))
+
+	chJobIdx := make(chan int)
+	waitGroup := sync.WaitGroup{}
+
+	for range numWorkers {
+		waitGroup.Go(func() {
+			for jobIdx := range chJobIdx {
+				job := jobs[jobIdx]
+
+				b, err := d.readFile(job.path)
+				if err != nil {
+					readErrs[jobIdx] = err
+
+					continue
+				}
+
+				job.setRaw(b)
+				parseErrs[jobIdx] = job.file.Unmarshal()
+			}
+		})
+	}
+
+	for jobIdx := range jobs {
+		chJobIdx <- jobIdx
+	}
+
+	close(chJobIdx)
+	waitGroup.Wait()
+
+	for _, err := range readErrs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return parseErrs, nil
+}
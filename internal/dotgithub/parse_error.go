@@ -0,0 +1,86 @@
+package dotgithub
+
+import (
+	"errors"
+	"io/fs"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var regexpYAMLErrorLine = regexp.MustCompile(`line ([0-9]+)`)
+
+// ParseError represents an action or workflow file that could not be parsed. Such a file is not linted by any rule
+// and it is reported instead.
+type ParseError struct {
+	Path string
+	Name string
+	Type int
+	// Line is the line number reported by the YAML parser, or 0 when it is not known. The parser does not report
+	// columns.
+	Line int
+	Err  error
+}
+
+// Error returns the message of the YAML parser without the prefixes added while unmarshaling the file. The line
+// number is left out when it is set in Line.
+func (e *ParseError) Error() string {
+	msg := e.Err.Error()
+
+	idx := strings.Index(msg, "yaml: ")
+	if idx >= 0 {
+		msg = msg[idx+len("yaml: "):]
+	}
+
+	if e.Line > 0 {
+		msg = strings.Replace(msg, "line "+strconv.Itoa(e.Line)+": ", "", 1)
+	}
+
+	return strings.Join(strings.Fields(msg), " ")
+}
+
+// Unwrap returns the original error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// newParseError returns a ParseError when err is caused by the file contents, or nil when it is an I/O error that
+// should abort reading the directory.
+func newParseError(path string, name string, fileType int, err error) *ParseError {
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return nil
+	}
+
+	parseErr := &ParseError{
+		Path: path,
+		Name: name,
+		Type: fileType,
+		Err:  err,
+	}
+
+	matches := regexpYAMLErrorLine.FindStringSubmatch(err.Error())
+	if len(matches) == 2 {
+		parseErr.Line, _ = strconv.Atoi(matches[1])
+	}
+
+	return parseErr
+}
+
+// GetParseError returns the error that occurred while parsing the file at the given path, or nil when the file was
+// parsed successfully.
+func (d *DotGithub) GetParseError(path string) *ParseError {
+	if d.ParseErrors == nil {
+		return nil
+	}
+
+	return d.ParseErrors[path]
+}
+
+func (d *DotGithub) addParseError(parseErr *ParseError) {
+	if d.ParseErrors == nil {
+		d.ParseErrors = make(map[string]*ParseError)
+	}
+
+	d.ParseErrors[parseErr.Path] = parseErr
+}
@@ -0,0 +1,109 @@
+package dotgithub
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"octo-linter/internal/action"
+	"octo-linter/internal/memfs"
+	"octo-linter/internal/workflow"
+)
+
+func TestParseError(t *testing.T) {
+	t.Parallel()
+
+	d, err := NewFromFiles(context.Background(), map[string][]byte{
+		"actions/broken/action.yml": []byte("name: Broken\nruns:\n  using: composite\n  steps: [\n"),
+		"actions/typed/action.yml":  []byte("name: Typed\ninputs: [a, b]\n"),
+		"workflows/broken.yml":      []byte("name: Broken\njobs:\n  main: runs-on: ubuntu-latest\n"),
+		"workflows/main.yml":        []byte("name: Main\non: push\njobs:\n  main:\n    runs-on: ubuntu-latest\n"),
+	})
+	if err != nil {
+		t.Fatalf("NewFromFiles failed with an error: %s", err.Error())
+	}
+
+	for _, tt := range []struct {
+		path     string
+		name     string
+		fileType int
+		line     int
+		msg      string
+	}{
+		{
+			path:     "actions/broken/action.yml",
+			name:     "broken",
+			fileType: action.DotGithubFileTypeAction,
+			line:     4,
+			msg:      "did not find expected node content",
+		},
+		{
+			path:     "actions/typed/action.yml",
+			name:     "typed",
+			fileType: action.DotGithubFileTypeAction,
+			line:     2,
+			msg:      "unmarshal errors: cannot unmarshal !!seq into map[string]*action.Input",
+		},
+		{
+			path:     "workflows/broken.yml",
+			name:     "broken",
+			fileType: workflow.DotGithubFileTypeWorkflow,
+			line:     3,
+			msg:      "mapping values are not allowed in this context",
+		},
+	} {
+		parseErr := d.GetParseError(tt.path)
+		if parseErr == nil {
+			t.Errorf("GetParseError should return an error for '%s'", tt.path)
+
+			continue
+		}
+
+		if parseErr.Name != tt.name || parseErr.Type != tt.fileType || parseErr.Line != tt.line {
+			t.Errorf(
+				"ParseError for '%s' should have name '%s', type %d and line %d, got %s, %d and %d",
+				tt.path,
+				tt.name,
+				tt.fileType,
+				tt.line,
+				parseErr.Name,
+				parseErr.Type,
+				parseErr.Line,
+			)
+		}
+
+		if parseErr.Error() != tt.msg {
+			t.Errorf("ParseError for '%s' should have message '%s', got '%s'", tt.path, tt.msg, parseErr.Error())
+		}
+	}
+
+	if d.GetParseError("workflows/main.yml") != nil || len(d.ParseErrors) != 3 {
+		t.Errorf("GetParseError should return errors only for the 3 unparsable files, got %v", d.ParseErrors)
+	}
+}
+
+func TestReadDirReadError(t *testing.T) {
+	t.Parallel()
+
+	fsys := memfs.New()
+	_ = fsys.AddFile("workflows/main.yml", []byte("name: Main\non: push\njobs: {}\n"))
+	// contents of the file are not available so reading it fails
+	_ = fsys.AddFileInfo("workflows/unreadable.yml", 10)
+
+	d := &DotGithub{FS: fsys}
+
+	err := d.ReadDir(context.Background(), ".", nil, nil)
+	if err == nil {
+		t.Errorf("ReadDir should return an error when a file cannot be read")
+	}
+
+	if d.GetParseError("workflows/unreadable.yml") != nil {
+		t.Errorf("ReadDir should not report a file that cannot be read as a parse error")
+	}
+
+	readErr := fmt.Errorf("cannot read file: %w", &fs.PathError{Op: "read", Path: "main.yml", Err: fs.ErrNotExist})
+	if newParseError("workflows/main.yml", "main", workflow.DotGithubFileTypeWorkflow, readErr) != nil {
+		t.Errorf("newParseError should return nil for an I/O error")
+	}
+}
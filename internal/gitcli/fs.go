@@ -0,0 +1,140 @@
+package gitcli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"octo-linter/internal/memfs"
+)
+
+// numLsTreeFields is the number of space-separated fields before the tab in 'git ls-tree --long' output.
+const numLsTreeFields = 4
+
+var errCatFileOutput = errors.New("unexpected output of git cat-file")
+
+// NewFS returns an fs.FS with all the files at revision rev of the repository containing dir. Files are read from
+// the objects of the local repository, so the working tree does not need to be checked out at that revision. Only
+// contents of files in the given paths, relative to the repository root, are read. Remaining files can only be listed
+// and stat'ed.
+func NewFS(ctx context.Context, dir string, rev string, paths ...string) (*memfs.FS, error) {
+	if rev == "" || strings.HasPrefix(rev, "-") {
+		return nil, fmt.Errorf("%w: '%s'", errInvalidRef, rev)
+	}
+
+	tree, err := run(ctx, dir, "ls-tree", "-r", "-z", "--long", "--full-tree", rev)
+	if err != nil {
+		return nil, err
+	}
+
+	fsys := memfs.New()
+	names := []string{}
+	objects := []string{}
+
+	for _, entry := range strings.Split(tree, "\x00") {
+		meta, name, found := strings.Cut(entry, "\t")
+		if !found {
+			continue
+		}
+
+		fields := strings.Fields(meta)
+		// skip symbolic links and submodules
+		if len(fields) != numLsTreeFields || fields[1] != "blob" || fields[0] == "120000" {
+			continue
+		}
+
+		size, err := strconv.ParseInt(fields[3], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		if !isInPaths(name, paths) {
+			_ = fsys.AddFileInfo(name, size)
+
+			continue
+		}
+
+		names = append(names, name)
+		objects = append(objects, fields[2])
+	}
+
+	contents, err := catFiles(ctx, dir, objects)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, name := range names {
+		_ = fsys.AddFile(name, contents[i])
+	}
+
+	return fsys, nil
+}
+
+func isInPaths(name string, paths []string) bool {
+	for _, p := range paths {
+		if p == "." || name == p || strings.HasPrefix(name, p+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// catFiles returns contents of the given blobs read with a single 'git cat-file' process.
+func catFiles(ctx context.Context, dir string, objects []string) ([][]byte, error) {
+	if len(objects) == 0 {
+		return [][]byte{}, nil
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "git", "cat-file", "--batch")
+	cmd.Dir = dir
+	cmd.Stdin = strings.NewReader(strings.Join(objects, "\n") + "\n")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return nil, errRunningGitCommand(cmd.Args[1:], strings.TrimSpace(stderr.String()), err)
+	}
+
+	reader := bufio.NewReader(&stdout)
+	contents := make([][]byte, len(objects))
+
+	for i := range objects {
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errCatFileOutput, err.Error())
+		}
+
+		// header is '<object> <type> <size>'
+		fields := strings.Fields(header)
+		if len(fields) != 3 || fields[1] != "blob" {
+			return nil, fmt.Errorf("%w: %s", errCatFileOutput, strings.TrimSpace(header))
+		}
+
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errCatFileOutput, err.Error())
+		}
+
+		// content is followed by a newline
+		contents[i] = make([]byte, size+1)
+
+		_, err = io.ReadFull(reader, contents[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errCatFileOutput, err.Error())
+		}
+
+		contents[i] = contents[i][:size]
+	}
+
+	return contents, nil
+}
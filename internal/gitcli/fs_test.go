@@ -0,0 +1,135 @@
+package gitcli
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestNewFS(t *testing.T) {
+	t.Parallel()
+
+	dir := newRepo(t, map[string]string{
+		".github/workflows/main.yml":   "name: Main\n",
+		".github/actions/a/action.yml": "name: A\n",
+		".github/actions/a/index.js":   "console.log('a')\n",
+		"action.yml":                   "name: Root\n",
+		"src/main.go":                  "package main\n",
+	})
+
+	err := os.Symlink("main.yml", filepath.Join(dir, ".github", "workflows", "link.yml"))
+	if err != nil {
+		t.Fatalf("cannot create symbolic link: %s", err.Error())
+	}
+
+	commit(t, dir, "add symbolic link")
+
+	// changes in the working tree are not visible at the revision
+	writeFiles(t, dir, map[string]string{
+		".github/workflows/main.yml": "name: Changed\n",
+		".github/workflows/new.yml":  "name: New\n",
+	})
+
+	fsys, err := NewFS(context.Background(), filepath.Join(dir, "src"), "HEAD", ".github", "action.yml")
+	if err != nil {
+		t.Fatalf("NewFS failed with an error: %s", err.Error())
+	}
+
+	entries, err := fs.ReadDir(fsys, ".github/workflows")
+	if err != nil {
+		t.Fatalf("ReadDir failed with an error: %s", err.Error())
+	}
+
+	names := []string{}
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+
+	if !slices.Equal(names, []string{"main.yml"}) {
+		t.Errorf("ReadDir should list committed files without symbolic links, got %v", names)
+	}
+
+	for path, expected := range map[string]string{
+		".github/workflows/main.yml": "name: Main\n",
+		".github/actions/a/index.js": "console.log('a')\n",
+		"action.yml":                 "name: Root\n",
+	} {
+		b, err := fs.ReadFile(fsys, path)
+		if err != nil || string(b) != expected {
+			t.Errorf("ReadFile(%s) should return '%s', got '%s' and %v", path, expected, b, err)
+		}
+	}
+
+	// files outside the given paths can be stat'ed but not read
+	fileInfo, err := fs.Stat(fsys, "src/main.go")
+	if err != nil || fileInfo.IsDir() || fileInfo.Size() != int64(len("package main\n")) {
+		t.Errorf("Stat should return the size of a file outside the paths, got %v and %v", fileInfo, err)
+	}
+
+	_, err = fs.ReadFile(fsys, "src/main.go")
+	if err == nil {
+		t.Errorf("ReadFile should return an error for a file outside the paths")
+	}
+
+	fileInfo, err = fs.Stat(fsys, ".github/actions/a")
+	if err != nil || !fileInfo.IsDir() {
+		t.Errorf("Stat should return a directory, got %v and %v", fileInfo, err)
+	}
+
+	_, err = fs.Stat(fsys, ".github/workflows/new.yml")
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Stat should return fs.ErrNotExist for a file that is not committed, got %v", err)
+	}
+
+	for _, rev := range []string{"", "--all", "missing"} {
+		_, err = NewFS(context.Background(), dir, rev, ".")
+		if err == nil {
+			t.Errorf("NewFS should return an error for revision '%s'", rev)
+		}
+	}
+}
+
+func TestGetRepositoryPath(t *testing.T) {
+	t.Parallel()
+
+	dir := newRepo(t, map[string]string{".github/workflows/main.yml": "name: Main\n"})
+
+	link := filepath.Join(t.TempDir(), "link")
+
+	err := os.Symlink(filepath.Join(dir, ".github"), link)
+	if err != nil {
+		t.Fatalf("cannot create symbolic link: %s", err.Error())
+	}
+
+	for _, tt := range []struct {
+		path     string
+		expected string
+	}{
+		{path: dir, expected: "."},
+		{path: filepath.Join(dir, ".github"), expected: ".github"},
+		{path: filepath.Join(dir, ".github", "workflows", "main.yml"), expected: ".github/workflows/main.yml"},
+		{path: filepath.Join(link, "workflows"), expected: ".github/workflows"},
+		// the path does not need to exist in the working tree
+		{path: filepath.Join(dir, "deleted", ".github"), expected: "deleted/.github"},
+	} {
+		root, relPath, err := GetRepositoryPath(context.Background(), tt.path)
+		if err != nil {
+			t.Errorf("GetRepositoryPath(%s) failed with an error: %s", tt.path, err.Error())
+
+			continue
+		}
+
+		if root != dir || relPath != tt.expected {
+			t.Errorf("GetRepositoryPath(%s) should return %s and %s, got %s and %s", tt.path, dir, tt.expected, root, relPath)
+		}
+	}
+
+	_, _, err = GetRepositoryPath(context.Background(), t.TempDir())
+	if err == nil {
+		t.Errorf("GetRepositoryPath should return an error for a path outside a git repository")
+	}
+}
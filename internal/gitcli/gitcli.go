@@ -0,0 +1,124 @@
+// Package gitcli runs the git command line tool to get information about the repository that is being linted.
+package gitcli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	errGitCommand  = errors.New("error running git command")
+	errInvalidRef  = errors.New("invalid git ref")
+	errOutsideRepo = errors.New("path is outside of the git repository")
+)
+
+func errRunningGitCommand(args []string, stderr string, err error) error {
+	return fmt.Errorf("%w 'git %s': %s: %s", errGitCommand, strings.Join(args, " "), err.Error(), stderr)
+}
+
+// GetChangedFiles returns absolute paths of files in the repository containing dir that were changed since the
+// common ancestor of ref and HEAD. Changes that are not committed yet, including untracked files, are returned as
+// well.
+func GetChangedFiles(ctx context.Context, dir string, ref string) ([]string, error) {
+	if ref == "" || strings.HasPrefix(ref, "-") {
+		return nil, fmt.Errorf("%w: '%s'", errInvalidRef, ref)
+	}
+
+	topLevel, err := run(ctx, dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+
+	mergeBase, err := run(ctx, dir, "merge-base", ref, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	changed, err := run(ctx, dir, "diff", "--name-only", "-z", strings.TrimSpace(mergeBase), "--")
+	if err != nil {
+		return nil, err
+	}
+
+	untracked, err := run(ctx, dir, "ls-files", "--others", "--exclude-standard", "--full-name", "-z")
+	if err != nil {
+		return nil, err
+	}
+
+	root := strings.TrimSpace(topLevel)
+	files := []string{}
+
+	for _, name := range strings.Split(changed+untracked, "\x00") {
+		if name == "" {
+			continue
+		}
+
+		files = append(files, filepath.Join(root, filepath.FromSlash(name)))
+	}
+
+	return files, nil
+}
+
+// GetRepositoryPath returns the root directory of the git repository containing path, and path relative to it. The
+// relative path is slash-separated, as used in FS. The path does not need to exist in the working tree.
+func GetRepositoryPath(ctx context.Context, path string) (string, string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", "", fmt.Errorf("error getting absolute path of %s: %w", path, err)
+	}
+
+	// find the closest existing directory to run git in and to resolve symbolic links, as git does
+	existing := absPath
+	for {
+		fileInfo, err := os.Stat(existing)
+		if err == nil && fileInfo.IsDir() {
+			break
+		}
+
+		if filepath.Dir(existing) == existing {
+			return "", "", fmt.Errorf("%w: %s", errOutsideRepo, path)
+		}
+
+		existing = filepath.Dir(existing)
+	}
+
+	realExisting, err := filepath.EvalSymlinks(existing)
+	if err != nil {
+		return "", "", fmt.Errorf("error resolving symbolic links in %s: %w", existing, err)
+	}
+
+	topLevel, err := run(ctx, existing, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", "", err
+	}
+
+	root := strings.TrimSpace(topLevel)
+
+	relPath, err := filepath.Rel(root, filepath.Join(realExisting, strings.TrimPrefix(absPath, existing)))
+	if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return "", "", fmt.Errorf("%w: %s", errOutsideRepo, path)
+	}
+
+	return root, filepath.ToSlash(relPath), nil
+}
+
+func run(ctx context.Context, dir string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return "", errRunningGitCommand(args, strings.TrimSpace(stderr.String()), err)
+	}
+
+	return stdout.String(), nil
+}
@@ -0,0 +1,119 @@
+package gitcli
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// newRepo creates a git repository in a temporary directory with the files committed on the 'main' branch, and
+// returns its path with symbolic links resolved.
+func newRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("cannot resolve temporary directory: %s", err.Error())
+	}
+
+	gitCmd(t, dir, "init", "-q", "-b", "main")
+	writeFiles(t, dir, files)
+	commit(t, dir, "initial commit")
+
+	return dir
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+
+		err := os.MkdirAll(filepath.Dir(path), 0o750)
+		if err != nil {
+			t.Fatalf("cannot create directory: %s", err.Error())
+		}
+
+		err = os.WriteFile(path, []byte(content), 0o600)
+		if err != nil {
+			t.Fatalf("cannot write file: %s", err.Error())
+		}
+	}
+}
+
+func commit(t *testing.T, dir string, message string) {
+	t.Helper()
+
+	gitCmd(t, dir, "add", "-A")
+	gitCmd(t, dir, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", message)
+}
+
+func gitCmd(t *testing.T, dir string, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed with an error: %s: %s", args, err.Error(), out)
+	}
+}
+
+func TestGetChangedFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := newRepo(t, map[string]string{
+		".github/workflows/main.yml":    "name: Main\n",
+		".github/workflows/release.yml": "name: Release\n",
+		".github/actions/a/action.yml":  "name: A\n",
+		".github/actions/b/action.yml":  "name: B\n",
+		"README.md":                     "# Repository\n",
+	})
+
+	gitCmd(t, dir, "checkout", "-q", "-b", "feature")
+	writeFiles(t, dir, map[string]string{".github/workflows/main.yml": "name: Changed main\n"})
+	commit(t, dir, "change main workflow")
+
+	// changes on the base branch after the fork point are not changes of the feature branch
+	gitCmd(t, dir, "checkout", "-q", "main")
+	writeFiles(t, dir, map[string]string{".github/workflows/release.yml": "name: Changed release\n"})
+	commit(t, dir, "change release workflow")
+	gitCmd(t, dir, "checkout", "-q", "feature")
+
+	// uncommitted and untracked changes are included
+	writeFiles(t, dir, map[string]string{
+		".github/actions/a/action.yml": "name: Changed A\n",
+		".github/actions/c/action.yml": "name: C\n",
+	})
+
+	files, err := GetChangedFiles(context.Background(), filepath.Join(dir, ".github"), "main")
+	if err != nil {
+		t.Fatalf("GetChangedFiles failed with an error: %s", err.Error())
+	}
+
+	slices.Sort(files)
+
+	expected := []string{
+		filepath.Join(dir, ".github", "actions", "a", "action.yml"),
+		filepath.Join(dir, ".github", "actions", "c", "action.yml"),
+		filepath.Join(dir, ".github", "workflows", "main.yml"),
+	}
+	if !slices.Equal(files, expected) {
+		t.Errorf("GetChangedFiles should return %v, got %v", expected, files)
+	}
+
+	for _, ref := range []string{"", "--output=x", "missing"} {
+		_, err = GetChangedFiles(context.Background(), dir, ref)
+		if err == nil {
+			t.Errorf("GetChangedFiles should return an error for ref '%s'", ref)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package linter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"gopkg.in/yaml.v2"
+	"octo-linter/internal/action"
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/glitch"
+	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/workflow"
+)
+
+const (
+	// DirModeCache sets the mode for the cache directory when it is created.
+	DirModeCache = 0o750
+)
+
+// Cache stores results of jobs on disk so that a rule is not run again on a file that did not change. Entries are
+// keyed by the rule, its config value, the octo-linter version and a hash of the file and of every file it depends
+// on, so they never need to be invalidated. Rules implementing rule.NotCacheable are not cached.
+type Cache struct {
+	dir     string
+	version string
+}
+
+type cacheEntry struct {
+	Compliant bool            `json:"compliant"`
+	Glitches  []glitch.Glitch `json:"glitches"`
+}
+
+// NewCache returns a Cache in the given directory, creating it when it does not exist.
+func NewCache(dir string, version string) (*Cache, error) {
+	err := os.MkdirAll(dir, DirModeCache)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cache directory %s: %w", dir, err)
+	}
+
+	return &Cache{dir: dir, version: version}, nil
+}
+
+// key returns the key of a job running the given rule on a file of the given type and hash. The rule is keyed by its
+// name in the configuration file and its value there, as rules parsed from the value can hold pointers, eg. compiled
+// regular expressions, that differ between runs. Whether the rule is an error is part of the key too, as stored
+// glitches have IsError set.
+func (c *Cache) key(checker rule.Checker, fileType int, isError bool, fileHash string) (string, error) {
+	// yaml.v2 sorts map keys so the value is encoded the same way in every run
+	value, err := yaml.Marshal(checker.ConfigValue())
+	if err != nil {
+		return "", fmt.Errorf("error encoding config value of %s: %w", checker.ConfigName(fileType), err)
+	}
+
+	h := sha256.New()
+	writeHashField(h, "version", []byte(c.version))
+	writeHashField(h, "rule", []byte(checker.ConfigName(fileType)))
+	writeHashField(h, "value", value)
+	writeHashField(h, "error", strconv.AppendBool(nil, isError))
+	writeHashField(h, "file", []byte(fileHash))
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// get returns the entry stored under the key. Missing and unreadable entries are treated as a miss.
+func (c *Cache) get(key string) (*cacheEntry, bool) {
+	b, err := os.ReadFile(c.getPath(key))
+	if err != nil {
+		return nil, false
+	}
+
+	entry := &cacheEntry{}
+
+	err = json.Unmarshal(b, entry)
+	if err != nil {
+		slog.Debug("ignoring invalid cache entry", slog.String("key", key), slog.String("err", err.Error()))
+
+		return nil, false
+	}
+
+	return entry, true
+}
+
+// set stores the entry under the key. The file is renamed into place so that concurrent runs never read a partial
+// entry. Errors are only logged as the cache is an optimisation.
+func (c *Cache) set(key string, entry *cacheEntry) {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		slog.Debug("error encoding cache entry", slog.String("key", key), slog.String("err", err.Error()))
+
+		return
+	}
+
+	tmpFile, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		slog.Debug("error creating cache entry", slog.String("key", key), slog.String("err", err.Error()))
+
+		return
+	}
+
+	_, err = tmpFile.Write(b)
+	closeErr := tmpFile.Close()
+
+	if err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tmpFile.Name(), c.getPath(key))
+	}
+
+	if err != nil {
+		_ = os.Remove(tmpFile.Name())
+
+		slog.Debug("error writing cache entry", slog.String("key", key), slog.String("err", err.Error()))
+	}
+}
+
+func (c *Cache) getPath(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// getFileHash returns a hash of everything, apart from the rule, that the result of linting the file depends on:
+// its contents, contents of files it uses, variable and secret names, and the overrides.
+func (l *Linter) getFileHash(dotGithub *dotgithub.DotGithub, file dotgithub.File) string {
+	h := sha256.New()
+
+	switch fileInstance := file.(type) {
+	case *action.Action:
+		writeHashField(h, "path", []byte(fileInstance.Path))
+		writeHashField(h, "raw", fileInstance.Raw)
+	case *workflow.Workflow:
+		writeHashField(h, "path", []byte(fileInstance.Path))
+		writeHashField(h, "raw", fileInstance.Raw)
+	}
+
+	deps := dotGithub.GetDependencies(file)
+	for _, name := range slices.Sorted(maps.Keys(deps)) {
+		writeHashField(h, "dependency:"+name, deps[name])
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(dotGithub.Vars)) {
+		writeHashField(h, "var", []byte(name))
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(dotGithub.Secrets)) {
+		writeHashField(h, "secret", []byte(name))
+	}
+
+	if l.Config.Overrides != nil {
+		writeHashField(h, "overrides", fmt.Appendf(
+			nil,
+			"%v %v",
+			l.Config.Overrides.ExternalActionsOutputsConfig,
+			l.Config.Overrides.ExternalActionsPaths,
+		))
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeHashField writes a named value prefixed with its length so that consecutive fields cannot be confused.
+func writeHashField(h hash.Hash, name string, value []byte) {
+	_, _ = fmt.Fprintf(h, "%s\x00%d\x00", name, len(value))
+	_, _ = h.Write(value)
+}
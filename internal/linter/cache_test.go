@@ -0,0 +1,179 @@
+package linter
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/glitch"
+	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/linter/rule/runners"
+)
+
+// countingRule is a value type, like the generated rules, with a counter shared between its copies.
+type countingRule struct {
+	numLint *atomic.Int32
+}
+
+func (r countingRule) Validate(_ interface{}) error { return nil }
+
+func (r countingRule) Lint(
+	_ interface{},
+	_ dotgithub.File,
+	_ *dotgithub.DotGithub,
+	chErrors chan<- glitch.Glitch,
+) (bool, error) {
+	r.numLint.Add(1)
+
+	chErrors <- glitch.Glitch{Name: "main.yml", RuleName: "counting", ErrText: "is not compliant"}
+
+	return false, nil
+}
+
+func (r countingRule) ConfigName(_ int) string { return "counting" }
+
+func (r countingRule) FileType() int { return rule.DotGithubFileTypeWorkflow }
+
+func runCachedJob(t *testing.T, l *Linter, d *dotgithub.DotGithub, ruleEntry rule.Rule) (bool, []glitch.Glitch) {
+	t.Helper()
+
+	checker, _ := rule.NewLegacyChecker(ruleEntry, true)
+
+	workflow := d.Workflows["main.yml"]
+	job := Job{
+		rule:      checker,
+		file:      workflow,
+		dotGithub: d,
+		isError:   true,
+	}
+
+	l.setJobCache(&job, rule.DotGithubFileTypeWorkflow, l.getFileHash(d, workflow))
+
+	chGlitches := make(chan glitch.Glitch, 10)
+
+	compliant, err := job.Run(context.Background(), chGlitches)
+	if err != nil {
+		t.Fatalf("Job.Run failed with an error: %s", err.Error())
+	}
+
+	close(chGlitches)
+
+	glitches := []glitch.Glitch{}
+	for glitchInstance := range chGlitches {
+		glitches = append(glitches, glitchInstance)
+	}
+
+	return compliant, glitches
+}
+
+func TestCacheReplaysGlitches(t *testing.T) {
+	t.Parallel()
+
+	files := map[string][]byte{
+		"actions/build/action.yml": []byte("name: Build\nruns:\n  using: composite\n  steps: []\n"),
+		"workflows/main.yml": []byte(
+			"name: Main\non: push\njobs:\n  main:\n    runs-on: ubuntu-latest\n    steps:\n" +
+				"      - uses: ./.github/actions/build\n",
+		),
+	}
+
+	d, err := dotgithub.NewFromFiles(context.Background(), files)
+	if err != nil {
+		t.Fatalf("NewFromFiles failed with an error: %s", err.Error())
+	}
+
+	cache, err := NewCache(t.TempDir(), "1.0.0")
+	if err != nil {
+		t.Fatalf("NewCache failed with an error: %s", err.Error())
+	}
+
+	l := &Linter{Config: &Config{}, Cache: cache}
+	ruleEntry := countingRule{numLint: &atomic.Int32{}}
+
+	for range 2 {
+		compliant, glitches := runCachedJob(t, l, d, ruleEntry)
+		if compliant || len(glitches) != 1 || glitches[0].ErrText != "is not compliant" {
+			t.Errorf("Job.Run should return the same result when it is cached, got %v and %v", compliant, glitches)
+		}
+	}
+
+	if ruleEntry.numLint.Load() != 1 {
+		t.Errorf("Job.Run should run the rule once and replay the cached glitches, ran %d times", ruleEntry.numLint.Load())
+	}
+
+	files["actions/build/action.yml"] = []byte("name: Build 2\nruns:\n  using: composite\n  steps: []\n")
+
+	d, err = dotgithub.NewFromFiles(context.Background(), files)
+	if err != nil {
+		t.Fatalf("NewFromFiles failed with an error: %s", err.Error())
+	}
+
+	runCachedJob(t, l, d, ruleEntry)
+
+	if ruleEntry.numLint.Load() != 2 {
+		t.Errorf("Job.Run should run the rule again when a used local action changes")
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	t.Parallel()
+
+	cache, err := NewCache(t.TempDir(), "1.0.0")
+	if err != nil {
+		t.Fatalf("NewCache failed with an error: %s", err.Error())
+	}
+
+	getKey := func(checker rule.Checker) string {
+		t.Helper()
+
+		key, err := cache.key(checker, rule.DotGithubFileTypeWorkflow, true, "hash")
+		if err != nil {
+			t.Fatalf("Cache.key failed with an error: %s", err.Error())
+		}
+
+		return key
+	}
+
+	newChecker := func(conf interface{}) rule.Checker {
+		t.Helper()
+
+		checker, err := rule.NewChecker(runners.SelfHostedNotOnForkPullRequests{}, conf)
+		if err != nil {
+			t.Fatalf("NewChecker failed with an error: %s", err.Error())
+		}
+
+		return checker
+	}
+
+	// parsed configs hold compiled regular expressions, which are different pointers in every run
+	key := getKey(newChecker([]interface{}{"^self-hosted$", "^gpu-.*$"}))
+	if getKey(newChecker([]interface{}{"^self-hosted$", "^gpu-.*$"})) != key {
+		t.Errorf("Cache.key should return the same key for the same config value")
+	}
+
+	if getKey(newChecker([]interface{}{"^self-hosted$"})) == key {
+		t.Errorf("Cache.key should return a different key for a different config value")
+	}
+
+	checker, _ := rule.NewLegacyChecker(countingRule{numLint: &atomic.Int32{}}, map[interface{}]interface{}{"b": 1, "a": 2})
+	for range 10 {
+		otherChecker, _ := rule.NewLegacyChecker(countingRule{numLint: &atomic.Int32{}}, map[interface{}]interface{}{"a": 2, "b": 1})
+		if getKey(otherChecker) != getKey(checker) {
+			t.Errorf("Cache.key should not depend on the order of map keys in the config value")
+		}
+	}
+
+	if !checker.IsCacheable() {
+		t.Errorf("IsCacheable should return true for a rule that does not implement rule.NotCacheable")
+	}
+
+	deprecatedImages, err := rule.NewChecker(runners.DeprecatedImages{}, true)
+	if err != nil {
+		t.Fatalf("NewChecker failed with an error: %s", err.Error())
+	}
+
+	if deprecatedImages.IsCacheable() {
+		t.Errorf("IsCacheable should return false for a rule that depends on the current date")
+	}
+}
@@ -19,11 +19,13 @@ var defaultConfig []byte
 type Config struct {
 	Version     string                            `yaml:"version"`
 	RulesConfig map[string]map[string]interface{} `yaml:"rules"`
-	Rules       []rule.Rule                       `yaml:"-"`
-	Values      []interface{}                     `yaml:"-"`
+	Rules       []rule.Checker                    `yaml:"-"`
 	WarningOnly map[string]struct{}               `yaml:"-"`
 	Overrides   *Overrides                        `yaml:"overrides,omitempty"`
 	Paths       *Paths                            `yaml:"paths,omitempty"`
+	// dir is the directory of the configuration file, which paths in rule values are relative to. It is empty for
+	// the default configuration.
+	dir string
 }
 
 // GetDefaultConfig returns a default configuration file.
@@ -38,6 +40,8 @@ func (cfg *Config) ReadFile(path string) error {
 		return fmt.Errorf("error reading file %s: %w", path, err)
 	}
 
+	cfg.dir = filepath.Dir(path)
+
 	err = cfg.readBytesAndValidate(b)
 	if err != nil {
 		return fmt.Errorf("error reading and/or validating config file %s: %w", path, err)
@@ -64,8 +68,7 @@ func (cfg *Config) IsError(rule string) bool {
 }
 
 func (cfg *Config) readBytesAndValidate(b []byte) error {
-	cfg.Rules = make([]rule.Rule, 0)
-	cfg.Values = make([]interface{}, 0)
+	cfg.Rules = make([]rule.Checker, 0)
 
 	err := yaml.Unmarshal(b, &cfg)
 	if err != nil {
@@ -80,10 +83,10 @@ func (cfg *Config) readBytesAndValidate(b []byte) error {
 		// Parse out rules that are only warnings. These are a list in "warning_only".
 		warningListInterface, keyExists := ruleGroup["warning_only"]
 		if keyExists {
-			warningList, castOk := warningListInterface.([]string)
+			warningList, castOk := warningListInterface.([]interface{})
 			if castOk {
 				for _, warningEntry := range warningList {
-					fullRuleName := fmt.Sprintf("%s__%s", ruleGroupName, warningEntry)
+					fullRuleName := fmt.Sprintf("%s__%v", ruleGroupName, warningEntry)
 					warningOnly[fullRuleName] = struct{}{}
 				}
 			}
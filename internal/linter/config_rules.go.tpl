@@ -11,32 +11,45 @@ import (
 	"octo-linter/internal/linter/rule/usedactions"
 	"octo-linter/internal/linter/rule/dependencies"
 	"octo-linter/internal/linter/rule/runners"
+	"octo-linter/internal/linter/rule/actionruns"
+	"octo-linter/internal/linter/rule/marketplace"
+	"octo-linter/internal/linter/rule/permissions"
+	"octo-linter/internal/linter/rule/injection"
+	"octo-linter/internal/linter/rule/triggers"
+	"octo-linter/internal/linter/rule/credentials"
+	"octo-linter/internal/linter/rule/leaks"
+	"octo-linter/internal/linter/rule/remotecode"
 )
 
 //nolint:gocognit,gocyclo,funlen,maintidx
 func (cfg *Config) addRuleFromConfig(fullRuleName string, ruleConfig interface{}) error {
-	var ruleInstance rule.Rule
+	var (
+		checker rule.Checker
+		err     error
+	)
 
 	switch fullRuleName {
 
   {{- range $configName, $structDetails := .Rules }}
 	case "{{ $configName }}":
-		ruleInstance = {{ $structDetails.N }}{
+		{{- if $structDetails.V2 }}
+		checker, err = rule.NewChecker({{ $structDetails.N }}{
+		{{- else }}
+		checker, err = rule.NewLegacyChecker({{ $structDetails.N }}{
+		{{- end }}
 			{{- range $fieldName, $fieldValue := $structDetails.F }}
 			{{ $fieldName }}: {{ $fieldValue }},
 			{{- end }}
-		}
-
-		err := ruleInstance.Validate(ruleConfig)
-		if err != nil {
-			return fmt.Errorf("rule validation error: %w", err)
-		}
+		}, ruleConfig)
   {{- end }}
 	}
 
-	if ruleInstance != nil {
-		cfg.Rules = append(cfg.Rules, ruleInstance)
-		cfg.Values = append(cfg.Values, ruleConfig)
+	if err != nil {
+		return fmt.Errorf("rule validation error: %w", err)
+	}
+
+	if checker != nil {
+		cfg.Rules = append(cfg.Rules, checker)
 	}
 
 	return nil
@@ -0,0 +1,108 @@
+package linter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigWarningOnly(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{}
+
+	err := cfg.readBytesAndValidate([]byte(`version: '3'
+rules:
+  filenames:
+    action_directory_name_format: dash-case
+    workflow_filename_base_format: dash-case
+    warning_only:
+      - action_directory_name_format
+`))
+	if err != nil {
+		t.Fatalf("Config.readBytesAndValidate failed with an error: %s", err.Error())
+	}
+
+	if cfg.IsError("filenames__action_directory_name_format") {
+		t.Errorf("Config.IsError should return false for a rule in 'warning_only'")
+	}
+
+	if !cfg.IsError("filenames__workflow_filename_base_format") {
+		t.Errorf("Config.IsError should return true for a rule not in 'warning_only'")
+	}
+}
+
+func TestDefaultConfigWarningOnly(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{}
+
+	err := cfg.ReadDefaultFile()
+	if err != nil {
+		t.Fatalf("Config.ReadDefaultFile failed with an error: %s", err.Error())
+	}
+
+	for _, ruleName := range []string{
+		"filenames__action_directory_name_format",
+		"workflow_runners__not_latest",
+		"used_actions_in_action_steps__source",
+	} {
+		if cfg.IsError(ruleName) {
+			t.Errorf("Config.IsError should return false for %s in the default config", ruleName)
+		}
+	}
+}
+
+func TestDefaultConfigPermissionsWarningOnly(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{}
+
+	err := cfg.ReadDefaultFile()
+	if err != nil {
+		t.Fatalf("Config.ReadDefaultFile failed with an error: %s", err.Error())
+	}
+
+	for ruleName := range cfg.RulesConfig["permissions"] {
+		if ruleName != "warning_only" && cfg.IsError("permissions__"+ruleName) {
+			t.Errorf("Config.IsError should return false for %s in the default config", ruleName)
+		}
+	}
+}
+
+func TestConfigAdvisoriesRelativeToConfigFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(dir, "advisories.yml"), []byte(`advisories:
+  - id: GHSA-0000-0000-0001
+    action: org/repo
+    ranges: ['< 2.0.0']
+`), 0o600)
+	if err != nil {
+		t.Fatalf("WriteFile failed with an error: %s", err.Error())
+	}
+
+	configPath := filepath.Join(dir, "dotgithub.yml")
+
+	err = os.WriteFile(configPath, []byte(`version: '3'
+rules:
+  used_actions_in_workflow_job_steps:
+    must_not_have_advisories: advisories.yml
+`), 0o600)
+	if err != nil {
+		t.Fatalf("WriteFile failed with an error: %s", err.Error())
+	}
+
+	cfg := &Config{}
+
+	err = cfg.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Config.ReadFile should read advisories next to the config file, got %s", err.Error())
+	}
+
+	if len(cfg.Rules) != 1 || cfg.Rules[0].IsCacheable() {
+		t.Errorf("Config.ReadFile should add a rule with advisories that is not cacheable, got %v", cfg.Rules)
+	}
+}
@@ -20,6 +20,38 @@ type Glitch struct {
 	RuleName string
 	ErrText  string
 	IsError  bool
+	// Position is where in the file the error is. It is zero when the rule does not know it.
+	Position Position
+	// Fix is an optional change that resolves the error.
+	Fix *Fix
+	// Related are other places, possibly in other files, that are relevant to the error.
+	Related []Location
+}
+
+// Position is a 1-based line and column in a file. Zero means unknown.
+type Position struct {
+	Line   int
+	Column int
+}
+
+// Location is a position in a file other than the one the error is reported for.
+type Location struct {
+	Path     string
+	Position Position
+	Message  string
+}
+
+// Fix is a suggested change to the file that resolves an error.
+type Fix struct {
+	Message string
+	Edits   []Edit
+}
+
+// Edit replaces the text between Start and End, exclusive, with NewText. When Start equals End, NewText is inserted.
+type Edit struct {
+	Start   Position
+	End     Position
+	NewText string
 }
 
 // ListToMarkdown takes a list of Glitch instances and generates a Markdown table from it.
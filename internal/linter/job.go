@@ -1,23 +1,28 @@
 package linter
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"time"
 
+	"octo-linter/internal/action"
 	"octo-linter/internal/dotgithub"
 	"octo-linter/internal/linter/glitch"
 	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/workflow"
 )
 
 const (
-	// SecondsJobTimeout sets the job timeout in seconds.
+	// SecondsJobTimeout sets the default job timeout in seconds.
 	SecondsJobTimeout = 10
 )
 
 var (
-	errLintTimeout = errors.New("lint timeout")
-	errLintError   = errors.New("lint error")
+	errLintTimeout  = errors.New("lint timeout")
+	errLintError    = errors.New("lint error")
+	errLintCanceled = errors.New("lint canceled")
 )
 
 func errRuleLintTimeout(name string) error {
@@ -28,42 +33,160 @@ func errRuleLintError(err error) error {
 	return fmt.Errorf("%w: %s", errLintError, err.Error())
 }
 
+func errRuleLintCanceled(err error) error {
+	return fmt.Errorf("%w: %s", errLintCanceled, err.Error())
+}
+
 // Job represents a single run of a rule against a .github file (action or workflow).
 type Job struct {
-	rule      rule.Rule
+	rule      rule.Checker
 	file      dotgithub.File
 	dotGithub *dotgithub.DotGithub
 	isError   bool
-	value     interface{}
+	// timeout limits how long the rule can run. When zero, SecondsJobTimeout is used.
+	timeout time.Duration
+	// cache, when set, is used to replay glitches of a previous run with the same cacheKey instead of running the
+	// rule.
+	cache    *Cache
+	cacheKey string
+	// warned is set by Run when the rule sent a warning, so that a compliant rule reporting only warnings is still
+	// counted as one.
+	warned bool
 }
 
-// Run execute the Job and sends any errors or warnings to specified channels.
-func (j *Job) Run(chWarnings chan<- glitch.Glitch, chErrors chan<- glitch.Glitch) (bool, error) {
+// Run executes the Job and sends any errors or warnings to the specified channel, with IsError set according to the
+// Job and severity of the diagnostic. It returns when the rule finishes, times out or ctx is canceled, and it never
+// sends to the channel afterwards.
+func (j *Job) Run(ctx context.Context, chGlitches chan<- glitch.Glitch) (bool, error) {
+	if ctx.Err() != nil {
+		return false, errRuleLintCanceled(ctx.Err())
+	}
+
+	if j.cache != nil {
+		entry, ok := j.cache.get(j.cacheKey)
+		if ok {
+			for _, glitchInstance := range entry.Glitches {
+				j.warned = j.warned || !glitchInstance.IsError
+				chGlitches <- glitchInstance
+			}
+
+			return entry.Compliant, nil
+		}
+	}
+
+	timeout := j.timeout
+	if timeout <= 0 {
+		timeout = SecondsJobTimeout * time.Second
+	}
+
+	jobCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	compliant := true
 
 	var err error
 
-	done := make(chan struct{})
-	timer := time.NewTimer(SecondsJobTimeout * time.Second)
+	// glitches are sent by the reporter to chLint, which is closed once the rule returns, and then forwarded to
+	// chGlitches
+	chLint := make(chan glitch.Glitch)
+	reporter := newReporter(j.file, j.rule.ConfigName(j.file.GetType()), j.isError, chLint)
 
 	go func() {
-		if j.isError {
-			compliant, err = j.rule.Lint(j.value, j.file, j.dotGithub, chErrors)
-		} else {
-			compliant, err = j.rule.Lint(j.value, j.file, j.dotGithub, chWarnings)
-		}
+		compliant, err = j.rule.Check(jobCtx, j.file, j.dotGithub, reporter)
 
-		close(done)
+		close(chLint)
 	}()
 
-	select {
-	case <-timer.C:
-		return false, errRuleLintTimeout(j.rule.ConfigName(j.file.GetType()))
-	case <-done:
-		if err != nil {
-			return compliant, errRuleLintError(err)
+	var glitches []glitch.Glitch
+
+	for {
+		select {
+		case glitchInstance, more := <-chLint:
+			if !more {
+				if err != nil {
+					return compliant, errRuleLintError(err)
+				}
+
+				j.warned = reporter.warned
+
+				if j.cache != nil {
+					j.cache.set(j.cacheKey, &cacheEntry{Compliant: compliant, Glitches: glitches})
+				}
+
+				return compliant, nil
+			}
+
+			glitches = append(glitches, glitchInstance)
+			chGlitches <- glitchInstance
+		case <-jobCtx.Done():
+			// the rule cannot be stopped, so its glitches are discarded until it returns
+			go func() {
+				for glitchInstance := range chLint {
+					slog.Debug(
+						"discarding glitch of a stopped job",
+						slog.String("path", glitchInstance.Path),
+						slog.String("rule", glitchInstance.RuleName),
+					)
+				}
+			}()
+
+			if ctx.Err() != nil {
+				return false, errRuleLintCanceled(ctx.Err())
+			}
+
+			return false, errRuleLintTimeout(j.rule.ConfigName(j.file.GetType()))
 		}
+	}
+}
+
+// path returns the path of the linted file.
+func (j *Job) path() string {
+	switch fileInstance := j.file.(type) {
+	case *action.Action:
+		return fileInstance.Path
+	case *workflow.Workflow:
+		return fileInstance.Path
+	default:
+		return ""
+	}
+}
 
-		return compliant, nil
+// reporter turns diagnostics of a rule into glitches of the linted file.
+type reporter struct {
+	glitch glitch.Glitch
+	ch     chan<- glitch.Glitch
+	// warned is true once a glitch that is not an error has been sent.
+	warned bool
+}
+
+func newReporter(file dotgithub.File, ruleName string, isError bool, ch chan<- glitch.Glitch) *reporter {
+	glitchInstance := glitch.Glitch{
+		Type:     file.GetType(),
+		RuleName: ruleName,
+		IsError:  isError,
+	}
+
+	switch fileInstance := file.(type) {
+	case *action.Action:
+		glitchInstance.Name = fileInstance.DirName
+		glitchInstance.Path = fileInstance.Path
+	case *workflow.Workflow:
+		glitchInstance.Name = fileInstance.DisplayName
+		glitchInstance.Path = fileInstance.Path
 	}
+
+	return &reporter{glitch: glitchInstance, ch: ch}
+}
+
+// Report sends the diagnostic as a glitch. A diagnostic with SeverityWarning is never an error.
+func (r *reporter) Report(diagnostic rule.Diagnostic) {
+	glitchInstance := r.glitch
+	glitchInstance.ErrText = diagnostic.Message
+	glitchInstance.IsError = r.glitch.IsError && diagnostic.Severity != rule.SeverityWarning
+	glitchInstance.Position = diagnostic.Position
+	glitchInstance.Fix = diagnostic.Fix
+	glitchInstance.Related = diagnostic.Related
+
+	r.warned = r.warned || !glitchInstance.IsError
+	r.ch <- glitchInstance
 }
@@ -1,6 +1,8 @@
 package linter
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -12,6 +14,7 @@ import (
 	"octo-linter/internal/dotgithub"
 	"octo-linter/internal/linter/glitch"
 	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/profile"
 )
 
 const (
@@ -21,28 +24,49 @@ const (
 	// HasErrors indicates that one or more rules failed and were classified as errors.
 	HasErrors
 
-	// HasOnlyWarnings indicates that some rules failed, but they were configured as warnings only.
+	// HasOnlyWarnings indicates that only warnings were reported, by rules configured as warnings only or as
+	// diagnostics with SeverityWarning.
 	HasOnlyWarnings
 )
 
 const (
-	// MillisecondsTickerCheckingChannelsClosed is the ticker interval (ms) for checking channel closure.
-	MillisecondsTickerCheckingChannelsClosed = 500
-
 	// FileModeOutputMarkdown sets the mode for the generated markdown summary file.
 	FileModeOutputMarkdown = 0o600
+
+	// RuleNameValidYAML is the name under which files that cannot be parsed are reported. It is not a configurable
+	// rule and such files are always reported as errors.
+	RuleNameValidYAML = "syntax__valid_yaml"
 )
 
 // Linter represents a linter with specific configuration.
 type Linter struct {
 	Config *Config
+	// Files limits reporting to the given action and workflow files. The remaining files are still loaded so that
+	// rules checking references between files work. When nil, all files are linted.
+	Files []string
+	// Cache, when set, stores results of rules so that they are not run again on files that did not change.
+	Cache *Cache
+	// Jobs is the number of rules run concurrently. When zero, the number of CPUs is used.
+	Jobs int
+	// Timeout limits the whole run. Jobs not finished by then are stopped and Lint returns an error. When zero,
+	// there is no limit.
+	Timeout time.Duration
+	// RuleTimeout limits a single run of a rule on a file. When zero, SecondsJobTimeout is used.
+	RuleTimeout time.Duration
+	// Profiler, when set, measures the whole run and each job.
+	Profiler *profile.Profiler
 }
 
-// Lint runs rules on the given DotGithub and returns the result.
+// Lint runs rules on the given DotGithub and returns the result. Canceling ctx stops the run.
 // Optionally writes a Markdown summary to an output file.
 //
-//nolint:gocognit,funlen
-func (l *Linter) Lint(dotGithub *dotgithub.DotGithub, output string, outputLimit int) (int, error) {
+//nolint:funlen
+func (l *Linter) Lint(
+	ctx context.Context,
+	dotGithub *dotgithub.DotGithub,
+	output string,
+	outputLimit int,
+) (int, error) {
 	if l.Config == nil {
 		panic("Config cannot be nil")
 	}
@@ -51,151 +75,55 @@ func (l *Linter) Lint(dotGithub *dotgithub.DotGithub, output string, outputLimit
 		panic("DotGithub cannot be empty")
 	}
 
-	summary := newSummary()
-	numCPU := runtime.NumCPU()
+	defer l.Profiler.Start(profile.CategoryLint, "lint", "", 0)()
 
-	chJobs := make(chan Job)
-	chWarnings := make(chan glitch.Glitch)
-	chErrors := make(chan glitch.Glitch)
+	summary := newSummary()
+	files := l.getFiles(dotGithub)
+	l.reportParseErrors(dotGithub, summary, files)
 
-	waitGroup := sync.WaitGroup{}
-	waitGroup.Add(numCPU)
+	if l.Timeout > 0 {
+		var cancel context.CancelFunc
 
-	go func() {
-		for _, action := range dotGithub.Actions {
-			if l.Config != nil && l.Config.Paths != nil && !l.Config.Paths.Check(action.Path) {
-				slog.Info("skipping action due to 'paths' configuration", slog.String("path", action.Path))
-				continue
-			}
-
-			for ruleIdx, ruleEntry := range l.Config.Rules {
-				if ruleEntry.FileType()&rule.DotGithubFileTypeAction == 0 {
-					continue
-				}
-
-				isError := l.Config.IsError(ruleEntry.ConfigName(rule.DotGithubFileTypeAction))
-				chJobs <- Job{
-					rule:      ruleEntry,
-					file:      action,
-					dotGithub: dotGithub,
-					isError:   isError,
-					value:     l.Config.Values[ruleIdx],
-				}
-
-				summary.numJob.Add(1)
-			}
-		}
+		ctx, cancel = context.WithTimeout(ctx, l.Timeout)
+		defer cancel()
+	}
 
-		for _, workflow := range dotGithub.Workflows {
-			if l.Config != nil && l.Config.Paths != nil && !l.Config.Paths.Check(workflow.Path) {
-				slog.Info("skipping workflow due to 'paths' configuration", slog.String("path", workflow.Path))
-				continue
-			}
+	numJobs := l.Jobs
+	if numJobs <= 0 {
+		numJobs = runtime.NumCPU()
+	}
 
-			for ruleIdx, ruleEntry := range l.Config.Rules {
-				if ruleEntry.FileType()&rule.DotGithubFileTypeWorkflow == 0 {
-					continue
-				}
-
-				isError := l.Config.IsError(ruleEntry.ConfigName(rule.DotGithubFileTypeWorkflow))
-				chJobs <- Job{
-					rule:      ruleEntry,
-					file:      workflow,
-					dotGithub: dotGithub,
-					isError:   isError,
-					value:     l.Config.Values[ruleIdx],
-				}
-
-				summary.numJob.Add(1)
-			}
-		}
+	chJobs := make(chan Job)
+	chGlitches := make(chan glitch.Glitch)
 
+	go func() {
+		l.produceJobs(ctx, dotGithub, files, summary, chJobs)
 		close(chJobs)
-		waitGroup.Done()
 	}()
 
-	go func() {
-		for {
-			job, more := <-chJobs
-			if !more {
-				close(chWarnings)
-				close(chErrors)
-
-				waitGroup.Done()
-
-				return
-			}
-
-			compliant, err := job.Run(chWarnings, chErrors)
-			if err != nil {
-				slog.Error(
-					"error running job",
-					slog.String("err", err.Error()),
-				)
-				summary.numError.Add(1)
-
-				continue
-			}
+	workers := sync.WaitGroup{}
 
-			if !compliant {
-				if job.isError {
-					summary.numError.Add(1)
-				} else {
-					summary.numWarning.Add(1)
-				}
+	for workerIdx := range numJobs {
+		workers.Go(func() {
+			for job := range chJobs {
+				l.runJob(ctx, &job, workerIdx+1, summary, chGlitches)
 			}
+		})
+	}
 
-			summary.numProcessed.Add(1)
-		}
+	go func() {
+		workers.Wait()
+		close(chGlitches)
 	}()
 
-	for range numCPU - 2 {
-		go func() {
-			chWarningsClosed := false
-			chErrorsClosed := false
-
-			ticker := time.NewTicker(MillisecondsTickerCheckingChannelsClosed * time.Millisecond)
-
-			for {
-				select {
-				case glitchInstance, more := <-chWarnings:
-					if more {
-						slog.Warn(
-							glitchInstance.ErrText,
-							slog.String("path", glitchInstance.Path),
-							slog.String("rule", glitchInstance.RuleName),
-						)
-
-						glitchInstance.IsError = false
-						summary.addGlitch(&glitchInstance)
-					} else {
-						chWarningsClosed = true
-					}
-				case glitchInstance, more := <-chErrors:
-					if more {
-						slog.Error(
-							glitchInstance.ErrText,
-							slog.String("path", glitchInstance.Path),
-							slog.String("rule", glitchInstance.RuleName),
-						)
-
-						glitchInstance.IsError = true
-						summary.addGlitch(&glitchInstance)
-					} else {
-						chErrorsClosed = true
-					}
-				case <-ticker.C:
-					if chWarningsClosed && chErrorsClosed {
-						waitGroup.Done()
-
-						return
-					}
-				}
-			}
-		}()
+	for glitchInstance := range chGlitches {
+		logGlitch(&glitchInstance)
+		summary.addGlitch(&glitchInstance)
 	}
 
-	waitGroup.Wait()
+	if ctx.Err() != nil {
+		return HasErrors, errRuleLintCanceled(ctx.Err())
+	}
 
 	finalStatus := HasNoErrorsOrWarnings
 
@@ -235,3 +163,253 @@ func (l *Linter) Lint(dotGithub *dotgithub.DotGithub, output string, outputLimit
 
 	return finalStatus, nil
 }
+
+// produceJobs sends a Job for every rule and every action and workflow that should be linted. It stops when ctx is
+// canceled.
+//
+//nolint:gocognit,funlen
+func (l *Linter) produceJobs(
+	ctx context.Context,
+	dotGithub *dotgithub.DotGithub,
+	files map[string]struct{},
+	summary *summary,
+	chJobs chan<- Job,
+) {
+	for _, action := range dotGithub.Actions {
+		if l.Config != nil && l.Config.Paths != nil && !l.Config.Paths.Check(action.Path) {
+			slog.Info("skipping action due to 'paths' configuration", slog.String("path", action.Path))
+			continue
+		}
+
+		if !isFileIncluded(files, action.Path) || dotGithub.GetParseError(action.Path) != nil {
+			continue
+		}
+
+		fileHash := ""
+		if l.Cache != nil {
+			fileHash = l.getFileHash(dotGithub, action)
+		}
+
+		for _, ruleEntry := range l.Config.Rules {
+			if ruleEntry.FileType()&rule.DotGithubFileTypeAction == 0 {
+				continue
+			}
+
+			isError := l.Config.IsError(ruleEntry.ConfigName(rule.DotGithubFileTypeAction))
+			job := Job{
+				rule:      ruleEntry,
+				file:      action,
+				dotGithub: dotGithub,
+				isError:   isError,
+				timeout:   l.RuleTimeout,
+			}
+
+			if l.Cache != nil && ruleEntry.IsCacheable() {
+				l.setJobCache(&job, rule.DotGithubFileTypeAction, fileHash)
+			}
+
+			select {
+			case chJobs <- job:
+			case <-ctx.Done():
+				return
+			}
+
+			summary.numJob.Add(1)
+		}
+	}
+
+	for _, workflow := range dotGithub.Workflows {
+		if l.Config != nil && l.Config.Paths != nil && !l.Config.Paths.Check(workflow.Path) {
+			slog.Info("skipping workflow due to 'paths' configuration", slog.String("path", workflow.Path))
+			continue
+		}
+
+		if !isFileIncluded(files, workflow.Path) || dotGithub.GetParseError(workflow.Path) != nil {
+			continue
+		}
+
+		fileHash := ""
+		if l.Cache != nil {
+			fileHash = l.getFileHash(dotGithub, workflow)
+		}
+
+		for _, ruleEntry := range l.Config.Rules {
+			if ruleEntry.FileType()&rule.DotGithubFileTypeWorkflow == 0 {
+				continue
+			}
+
+			isError := l.Config.IsError(ruleEntry.ConfigName(rule.DotGithubFileTypeWorkflow))
+			job := Job{
+				rule:      ruleEntry,
+				file:      workflow,
+				dotGithub: dotGithub,
+				isError:   isError,
+				timeout:   l.RuleTimeout,
+			}
+
+			if l.Cache != nil && ruleEntry.IsCacheable() {
+				l.setJobCache(&job, rule.DotGithubFileTypeWorkflow, fileHash)
+			}
+
+			select {
+			case chJobs <- job:
+			case <-ctx.Done():
+				return
+			}
+
+			summary.numJob.Add(1)
+		}
+	}
+}
+
+// runJob runs the Job and updates the summary with its result. The worker number is used to lay out the profile.
+func (l *Linter) runJob(
+	ctx context.Context,
+	job *Job,
+	worker int,
+	summary *summary,
+	chGlitches chan<- glitch.Glitch,
+) {
+	endSpan := l.Profiler.Start(profile.CategoryRule, job.rule.ConfigName(job.file.GetType()), job.path(), worker)
+	compliant, err := job.Run(ctx, chGlitches)
+
+	endSpan()
+	if err != nil {
+		// jobs stopped due to cancellation are reported once, by Lint
+		if errors.Is(err, errLintCanceled) {
+			return
+		}
+
+		slog.Error(
+			"error running job",
+			slog.String("err", err.Error()),
+		)
+		summary.numError.Add(1)
+
+		return
+	}
+
+	switch {
+	case !compliant && job.isError:
+		summary.numError.Add(1)
+	case !compliant, job.warned:
+		summary.numWarning.Add(1)
+	}
+
+	summary.numProcessed.Add(1)
+}
+
+// setJobCache sets the cache of the job. The job is run without the cache when its key cannot be created.
+func (l *Linter) setJobCache(job *Job, fileType int, fileHash string) {
+	key, err := l.Cache.key(job.rule, fileType, job.isError, fileHash)
+	if err != nil {
+		slog.Debug("running rule without cache", slog.String("err", err.Error()))
+
+		return
+	}
+
+	job.cache = l.Cache
+	job.cacheKey = key
+}
+
+// reportParseErrors adds files that could not be parsed to the summary as errors.
+func (l *Linter) reportParseErrors(dotGithub *dotgithub.DotGithub, summary *summary, files map[string]struct{}) {
+	for _, parseErr := range dotGithub.ParseErrors {
+		if !isFileIncluded(files, parseErr.Path) {
+			continue
+		}
+
+		if l.Config.Paths != nil && !l.Config.Paths.Check(parseErr.Path) {
+			slog.Info("skipping unparsable file due to 'paths' configuration", slog.String("path", parseErr.Path))
+			continue
+		}
+
+		glitchInstance := glitch.Glitch{
+			Type:     parseErr.Type,
+			Name:     parseErr.Name,
+			Path:     parseErr.Path,
+			RuleName: RuleNameValidYAML,
+			ErrText:  "is not a valid YAML file: " + parseErr.Error(),
+			IsError:  true,
+			Position: glitch.Position{Line: parseErr.Line},
+		}
+
+		logGlitch(&glitchInstance)
+		summary.addGlitch(&glitchInstance)
+		summary.numError.Add(1)
+	}
+}
+
+// logGlitch logs the glitch as an error or a warning. Position and fix are only logged when the rule provides them.
+func logGlitch(glitchInstance *glitch.Glitch) {
+	attrs := []any{slog.String("path", glitchInstance.Path)}
+
+	if glitchInstance.Position.Line > 0 {
+		attrs = append(attrs, slog.Int("line", glitchInstance.Position.Line))
+	}
+
+	if glitchInstance.Position.Column > 0 {
+		attrs = append(attrs, slog.Int("column", glitchInstance.Position.Column))
+	}
+
+	attrs = append(attrs, slog.String("rule", glitchInstance.RuleName))
+
+	if glitchInstance.Fix != nil {
+		attrs = append(attrs, slog.String("fix", glitchInstance.Fix.Message))
+	}
+
+	if glitchInstance.IsError {
+		slog.Error(glitchInstance.ErrText, attrs...)
+	} else {
+		slog.Warn(glitchInstance.ErrText, attrs...)
+	}
+}
+
+// getFiles returns absolute paths of Files, or nil when all files should be linted. Files that are not loaded as an
+// action or a workflow are logged.
+func (l *Linter) getFiles(dotGithub *dotgithub.DotGithub) map[string]struct{} {
+	if l.Files == nil {
+		return nil
+	}
+
+	loaded := map[string]struct{}{}
+	for _, action := range dotGithub.Actions {
+		loaded[getAbsPath(action.Path)] = struct{}{}
+	}
+
+	for _, workflow := range dotGithub.Workflows {
+		loaded[getAbsPath(workflow.Path)] = struct{}{}
+	}
+
+	files := make(map[string]struct{}, len(l.Files))
+
+	for _, file := range l.Files {
+		absPath := getAbsPath(file)
+		if _, ok := loaded[absPath]; !ok {
+			slog.Warn("file is not an action or a workflow and it is not linted", slog.String("path", file))
+		}
+
+		files[absPath] = struct{}{}
+	}
+
+	return files
+}
+
+func isFileIncluded(files map[string]struct{}, path string) bool {
+	if files == nil {
+		return true
+	}
+
+	_, ok := files[getAbsPath(path)]
+
+	return ok
+}
+
+func getAbsPath(path string) string {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+
+	return absPath
+}
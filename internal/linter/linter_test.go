@@ -0,0 +1,311 @@
+package linter
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/glitch"
+	"octo-linter/internal/linter/rule"
+)
+
+// blockingRule does not return until its channel is closed.
+type blockingRule struct {
+	chUnblock chan struct{}
+}
+
+func (r blockingRule) Validate(_ interface{}) error { return nil }
+
+func (r blockingRule) Lint(
+	_ interface{},
+	_ dotgithub.File,
+	_ *dotgithub.DotGithub,
+	chErrors chan<- glitch.Glitch,
+) (bool, error) {
+	<-r.chUnblock
+
+	chErrors <- glitch.Glitch{RuleName: "blocking", ErrText: "is sent after the job stopped"}
+
+	return true, nil
+}
+
+func (r blockingRule) ConfigName(_ int) string { return "blocking" }
+
+func (r blockingRule) FileType() int { return rule.DotGithubFileTypeWorkflow }
+
+func getWorkflowDotGithub(t *testing.T) *dotgithub.DotGithub {
+	t.Helper()
+
+	d, err := dotgithub.NewFromFiles(context.Background(), map[string][]byte{
+		"workflows/main.yml": []byte("name: Main\non: push\njobs:\n  main:\n    runs-on: ubuntu-latest\n"),
+	})
+	if err != nil {
+		t.Fatalf("NewFromFiles failed with an error: %s", err.Error())
+	}
+
+	return d
+}
+
+func TestJobRunTimeout(t *testing.T) {
+	t.Parallel()
+
+	d := getWorkflowDotGithub(t)
+	ruleEntry := blockingRule{chUnblock: make(chan struct{})}
+	chGlitches := make(chan glitch.Glitch)
+
+	checker, _ := rule.NewLegacyChecker(ruleEntry, true)
+	job := Job{rule: checker, file: d.Workflows["main.yml"], dotGithub: d, timeout: 10 * time.Millisecond}
+
+	_, err := job.Run(context.Background(), chGlitches)
+	if !errors.Is(err, errLintTimeout) {
+		t.Errorf("Job.Run should return a timeout error, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = job.Run(ctx, chGlitches)
+	if !errors.Is(err, errLintCanceled) {
+		t.Errorf("Job.Run should return a cancellation error when the context is canceled, got %v", err)
+	}
+
+	// the glitch sent by the stopped rule must be discarded rather than block or reach the channel
+	close(ruleEntry.chUnblock)
+
+	select {
+	case glitchInstance := <-chGlitches:
+		t.Errorf("Job.Run should not send glitches after it returns, got %v", glitchInstance)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestLintSingleJob(t *testing.T) {
+	t.Parallel()
+
+	d := getWorkflowDotGithub(t)
+	checker, _ := rule.NewLegacyChecker(countingRule{numLint: &atomic.Int32{}}, true)
+
+	l := &Linter{
+		Config: &Config{Rules: []rule.Checker{checker}},
+		Jobs:   1,
+	}
+
+	status, err := l.Lint(context.Background(), d, "", 0)
+	if err != nil {
+		t.Fatalf("Lint failed with an error: %s", err.Error())
+	}
+
+	if status != HasErrors {
+		t.Errorf("Lint should return HasErrors when a rule sends an error with a single job, got %d", status)
+	}
+}
+
+func TestLintTimeout(t *testing.T) {
+	t.Parallel()
+
+	d := getWorkflowDotGithub(t)
+	ruleEntry := blockingRule{chUnblock: make(chan struct{})}
+
+	defer close(ruleEntry.chUnblock)
+
+	checker, _ := rule.NewLegacyChecker(ruleEntry, true)
+
+	l := &Linter{
+		Config:  &Config{Rules: []rule.Checker{checker}},
+		Timeout: 10 * time.Millisecond,
+	}
+
+	_, err := l.Lint(context.Background(), d, "", 0)
+	if !errors.Is(err, errLintCanceled) {
+		t.Errorf("Lint should return a cancellation error when it times out, got %v", err)
+	}
+}
+
+// diagnosticRule reports a warning with a position, a fix and a related location.
+type diagnosticRule struct{}
+
+func (r diagnosticRule) ParseConfig(conf interface{}) (string, error) {
+	message, _ := conf.(string)
+
+	return message, nil
+}
+
+func (r diagnosticRule) Check(
+	_ context.Context,
+	conf string,
+	_ dotgithub.File,
+	_ *dotgithub.DotGithub,
+	reporter rule.Reporter,
+) (bool, error) {
+	reporter.Report(rule.Diagnostic{
+		Message:  conf,
+		Position: glitch.Position{Line: 5, Column: 14},
+		Severity: rule.SeverityWarning,
+		Fix:      &glitch.Fix{Message: "use a fixed runner image"},
+		Related:  []glitch.Location{{Path: ".github/actions/build/action.yml"}},
+	})
+
+	return false, nil
+}
+
+func (r diagnosticRule) ConfigName(_ int) string { return "diagnostic" }
+
+func (r diagnosticRule) FileType() int { return rule.DotGithubFileTypeWorkflow }
+
+func TestJobRunDiagnostic(t *testing.T) {
+	t.Parallel()
+
+	d := getWorkflowDotGithub(t)
+
+	checker, err := rule.NewChecker(diagnosticRule{}, "is not compliant")
+	if err != nil {
+		t.Fatalf("NewChecker failed with an error: %s", err.Error())
+	}
+
+	chGlitches := make(chan glitch.Glitch, 1)
+	job := Job{rule: checker, file: d.Workflows["main.yml"], dotGithub: d, isError: true}
+
+	compliant, err := job.Run(context.Background(), chGlitches)
+	if compliant || err != nil {
+		t.Fatalf("Job.Run should return false and no error, got %v and %v", compliant, err)
+	}
+
+	glitchInstance := <-chGlitches
+	if glitchInstance.ErrText != "is not compliant" || glitchInstance.Path != "workflows/main.yml" ||
+		glitchInstance.Name != "main" || glitchInstance.RuleName != "diagnostic" {
+		t.Errorf("Job.Run should fill in the file and the rule of the glitch, got %+v", glitchInstance)
+	}
+
+	if glitchInstance.IsError {
+		t.Errorf("Job.Run should report a diagnostic with SeverityWarning as a warning")
+	}
+
+	if glitchInstance.Position.Line != 5 || glitchInstance.Fix == nil || len(glitchInstance.Related) != 1 {
+		t.Errorf("Job.Run should keep position, fix and related locations of the diagnostic, got %+v", glitchInstance)
+	}
+}
+
+// warningRule reports a warning but returns that the file is compliant.
+type warningRule struct{}
+
+func (r warningRule) ParseConfig(_ interface{}) (bool, error) { return true, nil }
+
+func (r warningRule) Check(
+	_ context.Context,
+	_ bool,
+	_ dotgithub.File,
+	_ *dotgithub.DotGithub,
+	reporter rule.Reporter,
+) (bool, error) {
+	reporter.Report(rule.Diagnostic{Message: "could be improved", Severity: rule.SeverityWarning})
+
+	return true, nil
+}
+
+func (r warningRule) ConfigName(_ int) string { return "warning" }
+
+func (r warningRule) FileType() int { return rule.DotGithubFileTypeWorkflow }
+
+func TestLintCompliantRuleWithWarning(t *testing.T) {
+	t.Parallel()
+
+	d := getWorkflowDotGithub(t)
+
+	checker, err := rule.NewChecker(warningRule{}, true)
+	if err != nil {
+		t.Fatalf("NewChecker failed with an error: %s", err.Error())
+	}
+
+	l := &Linter{Config: &Config{Rules: []rule.Checker{checker}}}
+
+	status, err := l.Lint(context.Background(), d, "", 0)
+	if err != nil {
+		t.Fatalf("Lint failed with an error: %s", err.Error())
+	}
+
+	if status != HasOnlyWarnings {
+		t.Errorf("Lint should return HasOnlyWarnings when a compliant rule reports a warning, got %d", status)
+	}
+}
+
+func TestLintParseError(t *testing.T) {
+	t.Parallel()
+
+	d, err := dotgithub.NewFromFiles(context.Background(), map[string][]byte{
+		"workflows/main.yml":   []byte("name: Main\non: push\njobs:\n  main:\n    runs-on: ubuntu-latest\n"),
+		"workflows/broken.yml": []byte("name: Broken\njobs:\n  main: runs-on: ubuntu-latest\n"),
+	})
+	if err != nil {
+		t.Fatalf("NewFromFiles failed with an error: %s", err.Error())
+	}
+
+	l := &Linter{Config: &Config{}}
+	summary := newSummary()
+
+	l.reportParseErrors(d, summary, l.getFiles(d))
+
+	if len(summary.glitches) != 1 || summary.numError.Load() != 1 {
+		t.Fatalf("reportParseErrors should report one error, got %d", len(summary.glitches))
+	}
+
+	glitchInstance := summary.glitches[0]
+	if glitchInstance.Path != "workflows/broken.yml" || glitchInstance.RuleName != RuleNameValidYAML ||
+		!glitchInstance.IsError || glitchInstance.Position.Line != 3 {
+		t.Errorf("reportParseErrors should report the file with its line as an error, got %+v", glitchInstance)
+	}
+
+	if glitchInstance.ErrText != "is not a valid YAML file: mapping values are not allowed in this context" {
+		t.Errorf("reportParseErrors should not repeat the line in the message, got '%s'", glitchInstance.ErrText)
+	}
+
+	status, err := l.Lint(context.Background(), d, "", 0)
+	if err != nil {
+		t.Fatalf("Lint failed with an error: %s", err.Error())
+	}
+
+	if status != HasErrors {
+		t.Errorf("Lint should return HasErrors when a file cannot be parsed, got %d", status)
+	}
+
+	l.Files = []string{"workflows/main.yml"}
+
+	status, err = l.Lint(context.Background(), d, "", 0)
+	if err != nil || status != HasNoErrorsOrWarnings {
+		t.Errorf("Lint should not report an unparsable file that is not linted, got %d and %v", status, err)
+	}
+}
+
+func TestGetFilesAndIsFileIncluded(t *testing.T) {
+	t.Parallel()
+
+	d := getWorkflowDotGithub(t)
+
+	l := &Linter{}
+	if files := l.getFiles(d); files != nil || !isFileIncluded(files, "workflows/other.yml") {
+		t.Errorf("getFiles should return nil and include every file when Files is not set, got %v", files)
+	}
+
+	l.Files = []string{}
+	if files := l.getFiles(d); files == nil || isFileIncluded(files, "workflows/main.yml") {
+		t.Errorf("getFiles should return an empty set that excludes every file when Files is empty, got %v", files)
+	}
+
+	l.Files = []string{"./workflows/../workflows/main.yml", "workflows/README.md"}
+	files := l.getFiles(d)
+
+	for path, expected := range map[string]bool{
+		"workflows/main.yml":             true,
+		"./workflows/main.yml":           true,
+		getAbsPath("workflows/main.yml"): true,
+		"workflows/README.md":            true,
+		"workflows/other.yml":            false,
+		"main.yml":                       false,
+	} {
+		if isFileIncluded(files, path) != expected {
+			t.Errorf("isFileIncluded(%s) should return %v", path, expected)
+		}
+	}
+}
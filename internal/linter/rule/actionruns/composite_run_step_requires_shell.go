@@ -0,0 +1,81 @@
+package actionruns
+
+import (
+	"fmt"
+
+	"octo-linter/internal/action"
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/glitch"
+	"octo-linter/internal/linter/rule"
+)
+
+// CompositeRunStepRequiresShell checks whether steps of a composite action that have 'run' also have 'shell'.
+// Unlike in a workflow, there is no default shell in a composite action and such action fails when called.
+type CompositeRunStepRequiresShell struct{}
+
+// ConfigName returns the name of the rule as defined in the configuration file.
+func (r CompositeRunStepRequiresShell) ConfigName(int) string {
+	return "action_runs__composite_run_step_requires_shell"
+}
+
+// FileType returns an integer that specifies the file types (action and/or workflow) the rule targets.
+func (r CompositeRunStepRequiresShell) FileType() int {
+	return rule.DotGithubFileTypeAction
+}
+
+// Validate checks whether the given value is valid for this rule's configuration.
+func (r CompositeRunStepRequiresShell) Validate(conf interface{}) error {
+	_, ok := conf.(bool)
+	if !ok {
+		return errValueNotBool
+	}
+
+	return nil
+}
+
+// Lint runs a rule with the specified configuration on a dotgithub.File (action or workflow),
+// reports any errors via the given channel, and returns whether the file is compliant.
+func (r CompositeRunStepRequiresShell) Lint(
+	conf interface{},
+	file dotgithub.File,
+	_ *dotgithub.DotGithub,
+	chErrors chan<- glitch.Glitch,
+) (bool, error) {
+	confValue, confIsBool := conf.(bool)
+	if !confIsBool {
+		return false, errValueNotBool
+	}
+
+	if file.GetType() != rule.DotGithubFileTypeAction {
+		return true, nil
+	}
+
+	actionInstance, ok := file.(*action.Action)
+	if !ok {
+		return false, errFileInvalidType
+	}
+
+	if !confValue || actionInstance.Runs == nil || len(actionInstance.Runs.Steps) == 0 {
+		return true, nil
+	}
+
+	compliant := true
+
+	for stepIdx, step := range actionInstance.Runs.Steps {
+		if !step.IsRun() || step.Shell != "" {
+			continue
+		}
+
+		chErrors <- glitch.Glitch{
+			Path:     actionInstance.Path,
+			Name:     actionInstance.DirName,
+			Type:     rule.DotGithubFileTypeAction,
+			ErrText:  fmt.Sprintf("step %d has 'run' but does not have a required 'shell' field", stepIdx+1),
+			RuleName: r.ConfigName(0),
+		}
+
+		compliant = false
+	}
+
+	return compliant, nil
+}
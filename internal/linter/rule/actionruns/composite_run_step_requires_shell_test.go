@@ -0,0 +1,86 @@
+package actionruns
+
+import (
+	"strings"
+	"testing"
+
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/ruletest"
+)
+
+func TestCompositeRunStepRequiresShellValidate(t *testing.T) {
+	t.Parallel()
+
+	rule := CompositeRunStepRequiresShell{}
+
+	confBad := 4
+
+	err := rule.Validate(confBad)
+	if err == nil {
+		t.Errorf("CompositeRunStepRequiresShell.Validate should return error when conf is not bool")
+	}
+
+	confGood := true
+
+	err = rule.Validate(confGood)
+	if err != nil {
+		t.Errorf("CompositeRunStepRequiresShell.Validate should not return error when conf is bool")
+	}
+}
+
+func TestCompositeRunStepRequiresShellNotCompliant(t *testing.T) {
+	t.Parallel()
+
+	rule := CompositeRunStepRequiresShell{}
+	conf := true
+	d := ruletest.GetDotGithub()
+
+	fn := func(f dotgithub.File, _ string) {
+		compliant, ruleErrors, err := ruletest.Lint(2, rule, conf, f, d)
+		if compliant {
+			t.Errorf("CompositeRunStepRequiresShell.Lint should return false when 'run' step does not have 'shell'")
+		}
+
+		if err != nil {
+			t.Errorf("CompositeRunStepRequiresShell.Lint failed with an error: %s", err.Error())
+		}
+
+		if len(ruleErrors) != 2 {
+			t.Errorf(
+				"CompositeRunStepRequiresShell.Lint should send 2 errors over the channel, got [%s]",
+				strings.Join(ruleErrors, "\n"),
+			)
+		}
+	}
+
+	ruletest.Action(d, "actionruns-composite-run-step-requires-shell", fn)
+}
+
+func TestCompositeRunStepRequiresShellCompliant(t *testing.T) {
+	t.Parallel()
+
+	rule := CompositeRunStepRequiresShell{}
+	conf := true
+	d := ruletest.GetDotGithub()
+
+	fn := func(f dotgithub.File, _ string) {
+		compliant, ruleErrors, err := ruletest.Lint(2, rule, conf, f, d)
+		if !compliant {
+			t.Errorf("CompositeRunStepRequiresShell.Lint should return true when all 'run' steps have 'shell'")
+		}
+
+		if err != nil {
+			t.Errorf("CompositeRunStepRequiresShell.Lint failed with an error: %s", err.Error())
+		}
+
+		if len(ruleErrors) > 0 {
+			t.Errorf(
+				"CompositeRunStepRequiresShell.Lint should not send any error over the channel, sent %s",
+				strings.Join(ruleErrors, "|"),
+			)
+		}
+	}
+
+	ruletest.Action(d, "valid-action", fn)
+	ruletest.Action(d, dotgithub.RootActionName, fn)
+}
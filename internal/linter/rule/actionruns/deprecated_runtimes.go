@@ -0,0 +1,91 @@
+package actionruns
+
+import (
+	"fmt"
+
+	"octo-linter/internal/action"
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/glitch"
+	"octo-linter/internal/linter/rule"
+)
+
+// DeprecatedRuntimes checks whether 'runs.using' is not set to one of the runtimes that GitHub has deprecated, such
+// as 'node12' or 'node16'. Actions using them are forced to run on a newer runtime or fail.
+type DeprecatedRuntimes struct{}
+
+// ConfigName returns the name of the rule as defined in the configuration file.
+func (r DeprecatedRuntimes) ConfigName(int) string {
+	return "action_runs__deprecated_runtimes"
+}
+
+// FileType returns an integer that specifies the file types (action and/or workflow) the rule targets.
+func (r DeprecatedRuntimes) FileType() int {
+	return rule.DotGithubFileTypeAction
+}
+
+// Validate checks whether the given value is valid for this rule's configuration.
+func (r DeprecatedRuntimes) Validate(conf interface{}) error {
+	vals, ok := conf.([]interface{})
+	if !ok {
+		return errValueNotStringArray
+	}
+
+	for _, v := range vals {
+		_, ok := v.(string)
+		if !ok {
+			return errValueNotStringArray
+		}
+	}
+
+	return nil
+}
+
+// Lint runs a rule with the specified configuration on a dotgithub.File (action or workflow),
+// reports any errors via the given channel, and returns whether the file is compliant.
+func (r DeprecatedRuntimes) Lint(
+	conf interface{},
+	file dotgithub.File,
+	_ *dotgithub.DotGithub,
+	chErrors chan<- glitch.Glitch,
+) (bool, error) {
+	confInterfaces, confIsInterfaceArray := conf.([]interface{})
+	if !confIsInterfaceArray {
+		return false, errValueNotStringArray
+	}
+
+	if file.GetType() != rule.DotGithubFileTypeAction {
+		return true, nil
+	}
+
+	actionInstance, ok := file.(*action.Action)
+	if !ok {
+		return false, errFileInvalidType
+	}
+
+	if actionInstance.Runs == nil || actionInstance.Runs.Using == "" {
+		return true, nil
+	}
+
+	for _, runtimeInterface := range confInterfaces {
+		runtime, ok := runtimeInterface.(string)
+		if !ok {
+			return false, errValueNotStringArray
+		}
+
+		if actionInstance.Runs.Using != runtime {
+			continue
+		}
+
+		chErrors <- glitch.Glitch{
+			Path:     actionInstance.Path,
+			Name:     actionInstance.DirName,
+			Type:     rule.DotGithubFileTypeAction,
+			ErrText:  fmt.Sprintf("uses deprecated runtime '%s' in 'runs.using'", runtime),
+			RuleName: r.ConfigName(0),
+		}
+
+		return false, nil
+	}
+
+	return true, nil
+}
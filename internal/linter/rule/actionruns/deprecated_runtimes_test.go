@@ -0,0 +1,86 @@
+package actionruns
+
+import (
+	"strings"
+	"testing"
+
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/ruletest"
+)
+
+func TestDeprecatedRuntimesValidate(t *testing.T) {
+	t.Parallel()
+
+	rule := DeprecatedRuntimes{}
+
+	for _, confBad := range []interface{}{4, true, "node16", []interface{}{16}} {
+		err := rule.Validate(confBad)
+		if err == nil {
+			t.Errorf("DeprecatedRuntimes.Validate should return error when conf is %v", confBad)
+		}
+	}
+
+	confGood := []interface{}{"node12", "node16"}
+
+	err := rule.Validate(confGood)
+	if err != nil {
+		t.Errorf("DeprecatedRuntimes.Validate should not return error when conf is %v", confGood)
+	}
+}
+
+func TestDeprecatedRuntimesNotCompliant(t *testing.T) {
+	t.Parallel()
+
+	rule := DeprecatedRuntimes{}
+	conf := []interface{}{"node12", "node16"}
+	d := ruletest.GetDotGithub()
+
+	fn := func(f dotgithub.File, _ string) {
+		compliant, ruleErrors, err := ruletest.Lint(2, rule, conf, f, d)
+		if compliant {
+			t.Errorf("DeprecatedRuntimes.Lint should return false when action runs on node16")
+		}
+
+		if err != nil {
+			t.Errorf("DeprecatedRuntimes.Lint failed with an error: %s", err.Error())
+		}
+
+		if len(ruleErrors) != 1 {
+			t.Errorf(
+				"DeprecatedRuntimes.Lint should send 1 error over the channel, got [%s]",
+				strings.Join(ruleErrors, "\n"),
+			)
+		}
+	}
+
+	ruletest.Action(d, "actionruns-deprecated-runtimes", fn)
+}
+
+func TestDeprecatedRuntimesCompliant(t *testing.T) {
+	t.Parallel()
+
+	rule := DeprecatedRuntimes{}
+	conf := []interface{}{"node12", "node16"}
+	d := ruletest.GetDotGithub()
+
+	fn := func(f dotgithub.File, _ string) {
+		compliant, ruleErrors, err := ruletest.Lint(2, rule, conf, f, d)
+		if !compliant {
+			t.Errorf("DeprecatedRuntimes.Lint should return true when action does not use deprecated runtime")
+		}
+
+		if err != nil {
+			t.Errorf("DeprecatedRuntimes.Lint failed with an error: %s", err.Error())
+		}
+
+		if len(ruleErrors) > 0 {
+			t.Errorf(
+				"DeprecatedRuntimes.Lint should not send any error over the channel, sent %s",
+				strings.Join(ruleErrors, "|"),
+			)
+		}
+	}
+
+	ruletest.Action(d, "valid-action", fn)
+	ruletest.Action(d, "actionruns-referenced-files-exist", fn)
+}
@@ -0,0 +1,10 @@
+// Package actionruns contains rules checking the 'runs' section of JavaScript, Docker and composite actions.
+package actionruns
+
+import "errors"
+
+var (
+	errValueNotBool        = errors.New("value should be bool")
+	errValueNotStringArray = errors.New("value should be []string")
+	errFileInvalidType     = errors.New("file is of invalid type")
+)
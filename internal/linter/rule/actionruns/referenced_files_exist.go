@@ -0,0 +1,105 @@
+package actionruns
+
+import (
+	"fmt"
+
+	"octo-linter/internal/action"
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/glitch"
+	"octo-linter/internal/linter/rule"
+)
+
+// ReferencedFilesExist checks whether files referenced in 'runs' exist in the action directory. These are 'main',
+// 'pre' and 'post' scripts of a JavaScript action, and the Dockerfile of a Docker action that is not pulled from
+// a registry.
+type ReferencedFilesExist struct{}
+
+// ConfigName returns the name of the rule as defined in the configuration file.
+func (r ReferencedFilesExist) ConfigName(int) string {
+	return "action_runs__referenced_files_exist"
+}
+
+// FileType returns an integer that specifies the file types (action and/or workflow) the rule targets.
+func (r ReferencedFilesExist) FileType() int {
+	return rule.DotGithubFileTypeAction
+}
+
+// Validate checks whether the given value is valid for this rule's configuration.
+func (r ReferencedFilesExist) Validate(conf interface{}) error {
+	_, ok := conf.(bool)
+	if !ok {
+		return errValueNotBool
+	}
+
+	return nil
+}
+
+// Lint runs a rule with the specified configuration on a dotgithub.File (action or workflow),
+// reports any errors via the given channel, and returns whether the file is compliant.
+func (r ReferencedFilesExist) Lint(
+	conf interface{},
+	file dotgithub.File,
+	dotGithub *dotgithub.DotGithub,
+	chErrors chan<- glitch.Glitch,
+) (bool, error) {
+	confValue, confIsBool := conf.(bool)
+	if !confIsBool {
+		return false, errValueNotBool
+	}
+
+	if file.GetType() != rule.DotGithubFileTypeAction {
+		return true, nil
+	}
+
+	actionInstance, ok := file.(*action.Action)
+	if !ok {
+		return false, errFileInvalidType
+	}
+
+	if !confValue || actionInstance.Runs == nil {
+		return true, nil
+	}
+
+	compliant := true
+
+	for _, field := range r.getReferencedFiles(actionInstance.Runs) {
+		if dotGithub.IsActionFileExist(actionInstance, field[1]) {
+			continue
+		}
+
+		chErrors <- glitch.Glitch{
+			Path: actionInstance.Path,
+			Name: actionInstance.DirName,
+			Type: rule.DotGithubFileTypeAction,
+			ErrText: fmt.Sprintf(
+				"references file '%s' in 'runs.%s' that does not exist in the action directory",
+				field[1],
+				field[0],
+			),
+			RuleName: r.ConfigName(0),
+		}
+
+		compliant = false
+	}
+
+	return compliant, nil
+}
+
+// getReferencedFiles returns pairs of a field name and a file path it references.
+func (r ReferencedFilesExist) getReferencedFiles(runs *action.Runs) [][2]string {
+	files := [][2]string{}
+
+	if runs.IsNode() {
+		for _, field := range [][2]string{{"main", runs.Main}, {"pre", runs.Pre}, {"post", runs.Post}} {
+			if field[1] != "" {
+				files = append(files, field)
+			}
+		}
+	}
+
+	if runs.UsesDockerfile() {
+		files = append(files, [2]string{"image", runs.Image})
+	}
+
+	return files
+}
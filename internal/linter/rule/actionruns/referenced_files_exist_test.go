@@ -0,0 +1,86 @@
+package actionruns
+
+import (
+	"strings"
+	"testing"
+
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/ruletest"
+)
+
+func TestReferencedFilesExistValidate(t *testing.T) {
+	t.Parallel()
+
+	rule := ReferencedFilesExist{}
+
+	confBad := 4
+
+	err := rule.Validate(confBad)
+	if err == nil {
+		t.Errorf("ReferencedFilesExist.Validate should return error when conf is not bool")
+	}
+
+	confGood := true
+
+	err = rule.Validate(confGood)
+	if err != nil {
+		t.Errorf("ReferencedFilesExist.Validate should not return error when conf is bool")
+	}
+}
+
+func TestReferencedFilesExistNotCompliant(t *testing.T) {
+	t.Parallel()
+
+	rule := ReferencedFilesExist{}
+	conf := true
+	d := ruletest.GetDotGithub()
+
+	fn := func(f dotgithub.File, _ string) {
+		compliant, ruleErrors, err := ruletest.Lint(2, rule, conf, f, d)
+		if compliant {
+			t.Errorf("ReferencedFilesExist.Lint should return false when 'main' and 'post' files do not exist")
+		}
+
+		if err != nil {
+			t.Errorf("ReferencedFilesExist.Lint failed with an error: %s", err.Error())
+		}
+
+		if len(ruleErrors) != 2 {
+			t.Errorf(
+				"ReferencedFilesExist.Lint should send 2 errors over the channel, got [%s]",
+				strings.Join(ruleErrors, "\n"),
+			)
+		}
+	}
+
+	ruletest.Action(d, "actionruns-referenced-files-exist", fn)
+}
+
+func TestReferencedFilesExistCompliant(t *testing.T) {
+	t.Parallel()
+
+	rule := ReferencedFilesExist{}
+	conf := true
+	d := ruletest.GetDotGithub()
+
+	fn := func(f dotgithub.File, _ string) {
+		compliant, ruleErrors, err := ruletest.Lint(2, rule, conf, f, d)
+		if !compliant {
+			t.Errorf("ReferencedFilesExist.Lint should return true when all the referenced files exist")
+		}
+
+		if err != nil {
+			t.Errorf("ReferencedFilesExist.Lint failed with an error: %s", err.Error())
+		}
+
+		if len(ruleErrors) > 0 {
+			t.Errorf(
+				"ReferencedFilesExist.Lint should not send any error over the channel, sent %s",
+				strings.Join(ruleErrors, "|"),
+			)
+		}
+	}
+
+	ruletest.Action(d, "valid-action", fn)
+	ruletest.Action(d, "actionruns-deprecated-runtimes", fn)
+}
@@ -0,0 +1,136 @@
+package actionruns
+
+import (
+	"fmt"
+
+	"octo-linter/internal/action"
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/glitch"
+	"octo-linter/internal/linter/rule"
+)
+
+// UsingRequiredFields checks whether 'runs.using' contains a known value and whether the fields required by it are
+// defined, eg. 'main' for a JavaScript action, 'image' for a Docker action or 'steps' for a composite action.
+type UsingRequiredFields struct{}
+
+// ConfigName returns the name of the rule as defined in the configuration file.
+func (r UsingRequiredFields) ConfigName(int) string {
+	return "action_runs__using_required_fields"
+}
+
+// FileType returns an integer that specifies the file types (action and/or workflow) the rule targets.
+func (r UsingRequiredFields) FileType() int {
+	return rule.DotGithubFileTypeAction
+}
+
+// Validate checks whether the given value is valid for this rule's configuration.
+func (r UsingRequiredFields) Validate(conf interface{}) error {
+	_, ok := conf.(bool)
+	if !ok {
+		return errValueNotBool
+	}
+
+	return nil
+}
+
+// Lint runs a rule with the specified configuration on a dotgithub.File (action or workflow),
+// reports any errors via the given channel, and returns whether the file is compliant.
+func (r UsingRequiredFields) Lint(
+	conf interface{},
+	file dotgithub.File,
+	_ *dotgithub.DotGithub,
+	chErrors chan<- glitch.Glitch,
+) (bool, error) {
+	confValue, confIsBool := conf.(bool)
+	if !confIsBool {
+		return false, errValueNotBool
+	}
+
+	if file.GetType() != rule.DotGithubFileTypeAction {
+		return true, nil
+	}
+
+	actionInstance, ok := file.(*action.Action)
+	if !ok {
+		return false, errFileInvalidType
+	}
+
+	if !confValue {
+		return true, nil
+	}
+
+	if actionInstance.Runs == nil || actionInstance.Runs.Using == "" {
+		chErrors <- glitch.Glitch{
+			Path:     actionInstance.Path,
+			Name:     actionInstance.DirName,
+			Type:     rule.DotGithubFileTypeAction,
+			ErrText:  "does not have a required 'runs.using' field",
+			RuleName: r.ConfigName(0),
+		}
+
+		return false, nil
+	}
+
+	missingFields := r.getMissingFields(actionInstance.Runs)
+	if missingFields == nil {
+		chErrors <- glitch.Glitch{
+			Path:     actionInstance.Path,
+			Name:     actionInstance.DirName,
+			Type:     rule.DotGithubFileTypeAction,
+			ErrText:  fmt.Sprintf("has an unknown 'runs.using' value '%s'", actionInstance.Runs.Using),
+			RuleName: r.ConfigName(0),
+		}
+
+		return false, nil
+	}
+
+	for _, field := range missingFields {
+		chErrors <- glitch.Glitch{
+			Path: actionInstance.Path,
+			Name: actionInstance.DirName,
+			Type: rule.DotGithubFileTypeAction,
+			ErrText: fmt.Sprintf(
+				"does not have a 'runs.%s' field required when 'runs.using' is '%s'",
+				field,
+				actionInstance.Runs.Using,
+			),
+			RuleName: r.ConfigName(0),
+		}
+	}
+
+	return len(missingFields) == 0, nil
+}
+
+// getMissingFields returns names of the fields that are required by the 'using' value but are not defined. It
+// returns nil when the 'using' value is not known.
+func (r UsingRequiredFields) getMissingFields(runs *action.Runs) []string {
+	missingFields := []string{}
+
+	switch {
+	case runs.IsComposite():
+		if len(runs.Steps) == 0 {
+			missingFields = append(missingFields, "steps")
+		}
+	case runs.IsDocker():
+		if runs.Image == "" {
+			missingFields = append(missingFields, "image")
+		}
+	case isKnownNodeRuntime(runs.Using):
+		if runs.Main == "" {
+			missingFields = append(missingFields, "main")
+		}
+	default:
+		return nil
+	}
+
+	return missingFields
+}
+
+func isKnownNodeRuntime(using string) bool {
+	switch using {
+	case action.UsingNode12, action.UsingNode16, action.UsingNode20, action.UsingNode24:
+		return true
+	default:
+		return false
+	}
+}
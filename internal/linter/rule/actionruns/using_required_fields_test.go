@@ -0,0 +1,86 @@
+package actionruns
+
+import (
+	"strings"
+	"testing"
+
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/ruletest"
+)
+
+func TestUsingRequiredFieldsValidate(t *testing.T) {
+	t.Parallel()
+
+	rule := UsingRequiredFields{}
+
+	confBad := 4
+
+	err := rule.Validate(confBad)
+	if err == nil {
+		t.Errorf("UsingRequiredFields.Validate should return error when conf is not bool")
+	}
+
+	confGood := true
+
+	err = rule.Validate(confGood)
+	if err != nil {
+		t.Errorf("UsingRequiredFields.Validate should not return error when conf is bool")
+	}
+}
+
+func TestUsingRequiredFieldsNotCompliant(t *testing.T) {
+	t.Parallel()
+
+	rule := UsingRequiredFields{}
+	conf := true
+	d := ruletest.GetDotGithub()
+
+	fn := func(f dotgithub.File, _ string) {
+		compliant, ruleErrors, err := ruletest.Lint(2, rule, conf, f, d)
+		if compliant {
+			t.Errorf("UsingRequiredFields.Lint should return false when docker action does not have 'image'")
+		}
+
+		if err != nil {
+			t.Errorf("UsingRequiredFields.Lint failed with an error: %s", err.Error())
+		}
+
+		if len(ruleErrors) != 1 {
+			t.Errorf(
+				"UsingRequiredFields.Lint should send 1 error over the channel, got [%s]",
+				strings.Join(ruleErrors, "\n"),
+			)
+		}
+	}
+
+	ruletest.Action(d, "actionruns-using-required-fields", fn)
+}
+
+func TestUsingRequiredFieldsCompliant(t *testing.T) {
+	t.Parallel()
+
+	rule := UsingRequiredFields{}
+	conf := true
+	d := ruletest.GetDotGithub()
+
+	fn := func(f dotgithub.File, _ string) {
+		compliant, ruleErrors, err := ruletest.Lint(2, rule, conf, f, d)
+		if !compliant {
+			t.Errorf("UsingRequiredFields.Lint should return true when action has all the fields required by 'using'")
+		}
+
+		if err != nil {
+			t.Errorf("UsingRequiredFields.Lint failed with an error: %s", err.Error())
+		}
+
+		if len(ruleErrors) > 0 {
+			t.Errorf(
+				"UsingRequiredFields.Lint should not send any error over the channel, sent %s",
+				strings.Join(ruleErrors, "|"),
+			)
+		}
+	}
+
+	ruletest.Action(d, "valid-action", fn)
+	ruletest.Action(d, "actionruns-deprecated-runtimes", fn)
+}
@@ -0,0 +1,186 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	"octo-linter/internal/action"
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/glitch"
+	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/workflow"
+)
+
+// HardcodedConfig is the configuration of Hardcoded.
+type HardcodedConfig struct {
+	Enabled bool
+	// AllowedValues contains values that are not reported, eg. sample keys from documentation.
+	AllowedValues []string
+}
+
+// Hardcoded checks whether string values in the file, eg. in 'env' or 'with', do not contain credentials in
+// well-known formats, such as GitHub or Slack tokens, AWS access keys and private keys, or random-looking values
+// assigned to keys named like credentials, eg. 'API_TOKEN'. Such values should be stored in secrets.
+type Hardcoded struct {
+	FileTypeRequired string
+}
+
+// ConfigName returns the name of the rule as defined in the configuration file.
+func (r Hardcoded) ConfigName(t int) string {
+	switch t {
+	case rule.DotGithubFileTypeWorkflow:
+		return "hardcoded_credentials__workflow_not_allowed"
+	case rule.DotGithubFileTypeAction:
+		return "hardcoded_credentials__action_not_allowed"
+	default:
+		return "hardcoded_credentials__*_not_allowed"
+	}
+}
+
+// FileType returns an integer that specifies the file types (action and/or workflow) the rule targets.
+func (r Hardcoded) FileType() int {
+	return rule.GetFileTypeRequired(r.FileTypeRequired)
+}
+
+// ParseConfig checks whether the given value is valid for this rule's configuration and returns it as
+// HardcodedConfig. The value can be bool, or a map with 'allowed_values' key, which enables the rule.
+func (r Hardcoded) ParseConfig(conf interface{}) (HardcodedConfig, error) {
+	var parsedConf HardcodedConfig
+
+	switch confValue := conf.(type) {
+	case bool:
+		parsedConf.Enabled = confValue
+	case map[interface{}]interface{}:
+		parsedConf.Enabled = true
+
+		for key, value := range confValue {
+			if key != "allowed_values" {
+				return parsedConf, errValueNotBoolOrMap
+			}
+
+			values, ok := value.([]interface{})
+			if !ok {
+				return parsedConf, errValueNotStringArray
+			}
+
+			for _, v := range values {
+				str, ok := v.(string)
+				if !ok {
+					return parsedConf, errValueNotStringArray
+				}
+
+				parsedConf.AllowedValues = append(parsedConf.AllowedValues, str)
+			}
+		}
+	default:
+		return parsedConf, errValueNotBoolOrMap
+	}
+
+	return parsedConf, nil
+}
+
+// Check runs a rule with the specified configuration on a dotgithub.File (action or workflow), reports any errors
+// to the reporter, and returns whether the file is compliant.
+func (r Hardcoded) Check(
+	_ context.Context,
+	conf HardcodedConfig,
+	file dotgithub.File,
+	_ *dotgithub.DotGithub,
+	reporter rule.Reporter,
+) (bool, error) {
+	var raw []byte
+
+	switch fileInstance := file.(type) {
+	case *action.Action:
+		raw = fileInstance.Raw
+	case *workflow.Workflow:
+		raw = fileInstance.Raw
+	default:
+		return false, errFileInvalidType
+	}
+
+	if !conf.Enabled {
+		return true, nil
+	}
+
+	var parsed yaml.MapSlice
+
+	err := yaml.Unmarshal(raw, &parsed)
+	if err != nil {
+		// the file cannot be parsed, which is reported when it is read
+		return true, nil //nolint:nilerr
+	}
+
+	compliant := true
+	positions := rule.NewPositions(raw)
+
+	walkStrings(parsed, nil, func(keyPath []string, value string) {
+		// the first line of every value is looked up, so that repeated values get their own positions
+		firstLine, _, _ := strings.Cut(value, "\n")
+		position := positions.Next(firstLine)
+
+		found := r.findCredential(conf, keyPath[len(keyPath)-1], value)
+		if found == "" {
+			return
+		}
+
+		compliant = false
+
+		reporter.Report(rule.Diagnostic{
+			Message: fmt.Sprintf("'%s' contains a hardcoded %s", strings.Join(keyPath, "."), found),
+			Position: position,
+			Fix: &glitch.Fix{
+				Message: fmt.Sprintf(
+					"store the value in a secret and use '${{ secrets.%s }}' instead",
+					getSecretName(keyPath[len(keyPath)-1]),
+				),
+			},
+		})
+	})
+
+	return compliant, nil
+}
+
+// findCredential returns the kind of credential found in the value, or an empty string.
+func (r Hardcoded) findCredential(conf HardcodedConfig, key string, value string) string {
+	if slices.Contains(conf.AllowedValues, value) {
+		return ""
+	}
+
+	for _, format := range credentialFormats {
+		for _, match := range format.regexp.FindAllString(value, -1) {
+			if !slices.Contains(conf.AllowedValues, match) {
+				return format.name
+			}
+		}
+	}
+
+	if isHighEntropyCredential(key, value) {
+		return "credential"
+	}
+
+	return ""
+}
+
+// walkStrings calls fn for each string value in the YAML document, with the path of keys, and list indexes, to it.
+// Values are walked in the order they appear in the file.
+func walkStrings(node interface{}, keyPath []string, fn func(keyPath []string, value string)) {
+	switch value := node.(type) {
+	case string:
+		if len(keyPath) > 0 {
+			fn(keyPath, value)
+		}
+	case yaml.MapSlice:
+		for _, item := range value {
+			walkStrings(item.Value, append(slices.Clone(keyPath), fmt.Sprint(item.Key)), fn)
+		}
+	case []interface{}:
+		for i, item := range value {
+			walkStrings(item, append(slices.Clone(keyPath), strconv.Itoa(i)), fn)
+		}
+	}
+}
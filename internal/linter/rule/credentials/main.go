@@ -0,0 +1,92 @@
+// Package credentials contains rules checking for credentials, such as tokens or private keys, hardcoded in actions
+// and workflows.
+package credentials
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strings"
+)
+
+var (
+	errValueNotBoolOrMap   = errors.New("value should be bool or map with 'allowed_values' key")
+	errValueNotStringArray = errors.New("value should be []string")
+	errFileInvalidType     = errors.New("file is of invalid type")
+)
+
+const (
+	// minHighEntropyLength is the minimum length of a value assigned to a key named like a credential to be checked
+	// for entropy. Shorter values are usually placeholders.
+	minHighEntropyLength = 16
+	// minHighEntropy is the minimum Shannon entropy, in bits per character, of a random-looking value.
+	minHighEntropy = 3.5
+)
+
+// credentialFormat is a well-known format of a token or a key.
+type credentialFormat struct {
+	name   string
+	regexp *regexp.Regexp
+}
+
+//nolint:gochecknoglobals
+var credentialFormats = []credentialFormat{
+	{name: "GitHub token", regexp: regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36}\b`)},
+	{name: "GitHub fine-grained personal access token", regexp: regexp.MustCompile(`\bgithub_pat_[A-Za-z0-9_]{50,}`)},
+	{name: "AWS access key", regexp: regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{name: "Slack token", regexp: regexp.MustCompile(`\bxox[abposr]-[0-9A-Za-z\-]{10,}`)},
+	{
+		name:   "Slack webhook URL",
+		regexp: regexp.MustCompile(`https://hooks\.slack\.com/services/T[A-Z0-9]+/B[A-Z0-9]+/[A-Za-z0-9]+`),
+	},
+	{
+		name:   "private key",
+		regexp: regexp.MustCompile(`-----BEGIN ((RSA|DSA|EC|OPENSSH|PGP|ENCRYPTED) )?PRIVATE KEY( BLOCK)?-----`),
+	},
+}
+
+var (
+	regexpCredentialKey = regexp.MustCompile(
+		`(?i)(token|password|passwd|secret|api[_\-]?key|access[_\-]?key|private[_\-]?key)$`,
+	)
+	regexpNotSecretNameChar = regexp.MustCompile(`[^A-Z0-9_]`)
+)
+
+// getEntropy returns the Shannon entropy of the string in bits per character.
+func getEntropy(s string) float64 {
+	counts := map[rune]int{}
+	for _, c := range s {
+		counts[c]++
+	}
+
+	length := float64(len([]rune(s)))
+	entropy := 0.0
+
+	for _, count := range counts {
+		p := float64(count) / length
+		entropy -= p * math.Log2(p)
+	}
+
+	return entropy
+}
+
+// isHighEntropyCredential checks whether the value assigned to the key looks like a random credential: the key is
+// named like one, eg. 'API_TOKEN', and the value is long, random and not an expression, eg. '${{ secrets.TOKEN }}'.
+func isHighEntropyCredential(key string, value string) bool {
+	if !regexpCredentialKey.MatchString(key) || len(value) < minHighEntropyLength ||
+		strings.Contains(value, "${{") || strings.ContainsAny(value, " \t\n") {
+		return false
+	}
+
+	return getEntropy(value) >= minHighEntropy
+}
+
+// getSecretName returns a name of a secret that the value of the key can be moved to, eg. 'API_TOKEN', or '<name>'
+// when the key is not named like a credential, eg. 'run'.
+func getSecretName(key string) string {
+	if !regexpCredentialKey.MatchString(key) {
+		return "<name>"
+	}
+
+	return regexpNotSecretNameChar.ReplaceAllString(strings.ToUpper(key), "_")
+}
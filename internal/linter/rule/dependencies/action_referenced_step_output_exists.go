@@ -129,6 +129,11 @@ func (r ActionReferencedStepOutputExists) Lint(
 		if regexpLocal.MatchString(step.Uses) {
 			actionName := strings.ReplaceAll(step.Uses, "./.github/actions/", "")
 			foundAction = dotGithub.GetAction(actionName)
+
+			// outputs of an action that could not be parsed are not known, and the action is reported as invalid YAML
+			if foundAction != nil && dotGithub.GetParseError(foundAction.Path) != nil {
+				continue
+			}
 		}
 		// external action
 		if regexpExternal.MatchString(step.Uses) {
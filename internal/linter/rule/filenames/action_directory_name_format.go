@@ -58,6 +58,11 @@ func (r ActionDirectoryNameFormat) Lint(
 		return false, errFileInvalidType
 	}
 
+	// root action's directory is the repository itself, not a directory the action author can name
+	if actionInstance.DirName == dotgithub.RootActionName {
+		return true, nil
+	}
+
 	m := casematch.Match(actionInstance.DirName, confValue)
 	if !m {
 		chErrors <- glitch.Glitch{
@@ -0,0 +1,63 @@
+// Package injection contains rules checking for script injection, eg. untrusted values expanded in 'run' scripts.
+package injection
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var (
+	errValueNotBool    = errors.New("value should be bool")
+	errFileInvalidType = errors.New("file is of invalid type")
+)
+
+var (
+	regexpExpression = regexp.MustCompile(`\${{(.*?)}}`)
+	regexpContextRef = regexp.MustCompile(
+		`[a-zA-Z_][a-zA-Z0-9_\-]*(?:\.[a-zA-Z0-9_\-\*]+|\[\s*['"]?[a-zA-Z0-9_\-\*]+['"]?\s*\])*`,
+	)
+	regexpIndex          = regexp.MustCompile(`\[\s*['"]?([a-zA-Z0-9_\-\*]+)['"]?\s*\]`)
+	regexpNumericSegment = regexp.MustCompile(`\.[0-9]+(\.|$)`)
+	regexpNotEnvNameChar = regexp.MustCompile(`[^A-Z0-9_]`)
+)
+
+// regexpsUntrustedContext match context values that can be set by whoever opens an issue or a pull request, pushes
+// a commit or a branch, etc. References are lowercase and use '.*' for array indexes, see normalizeContextRef.
+//
+//nolint:gochecknoglobals
+var regexpsUntrustedContext = []*regexp.Regexp{
+	regexp.MustCompile(`^github\.head_ref$`),
+	regexp.MustCompile(`^github\.event\.[a-z_]+\.(title|body)$`),
+	regexp.MustCompile(`^github\.event\.[a-z_]+\.head_ref$`),
+	regexp.MustCompile(`^github\.event\.pull_request\.head\.(ref|label|repo\.default_branch)$`),
+	regexp.MustCompile(`^github\.event\.(head_commit|workflow_run\.head_commit)\.(message|author\.(email|name))$`),
+	regexp.MustCompile(`^github\.event\.commits\.\*\.(message|author\.(email|name))$`),
+	regexp.MustCompile(`^github\.event\.pages\.\*\.page_name$`),
+	regexp.MustCompile(`^github\.event\.workflow_run\.(head_branch|display_title)$`),
+	regexp.MustCompile(`^github\.event\.workflow_run\.pull_requests\.\*\.head\.ref$`),
+}
+
+// normalizeContextRef converts a context reference to lowercase, with the property dereference syntax only, and
+// array indexes replaced with '*', eg. "github.event['commits'][0].message" to 'github.event.commits.*.message'.
+func normalizeContextRef(ref string) string {
+	ref = strings.ToLower(ref)
+	ref = regexpIndex.ReplaceAllString(ref, ".$1")
+
+	for regexpNumericSegment.MatchString(ref) {
+		ref = regexpNumericSegment.ReplaceAllString(ref, ".*$1")
+	}
+
+	return ref
+}
+
+// getEnvName returns a name of an environment variable that the value of a context reference can be moved to, eg.
+// 'PULL_REQUEST_TITLE' for 'github.event.pull_request.title'.
+func getEnvName(ref string) string {
+	segments := strings.Split(strings.ReplaceAll(ref, ".*", ""), ".")
+	if len(segments) > 2 { //nolint:mnd
+		segments = segments[len(segments)-2:]
+	}
+
+	return regexpNotEnvNameChar.ReplaceAllString(strings.ToUpper(strings.Join(segments, "_")), "_")
+}
@@ -0,0 +1,213 @@
+package injection
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"octo-linter/internal/action"
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/glitch"
+	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/step"
+	"octo-linter/internal/workflow"
+)
+
+const githubScriptAction = "actions/github-script"
+
+// UntrustedContexts checks whether 'run' scripts and the 'script' input of 'actions/github-script' do not expand
+// untrusted values with '${{ }}', eg. a pull request title. Such a value is pasted into the script before it is run,
+// so it can inject commands. Free text inputs of 'workflow_dispatch' are untrusted as well. The value should be
+// passed through 'env' instead.
+type UntrustedContexts struct {
+	FileTypeRequired string
+}
+
+// ConfigName returns the name of the rule as defined in the configuration file.
+func (r UntrustedContexts) ConfigName(t int) string {
+	switch t {
+	case rule.DotGithubFileTypeWorkflow:
+		return "script_injection__workflow_untrusted_contexts_not_allowed"
+	case rule.DotGithubFileTypeAction:
+		return "script_injection__action_untrusted_contexts_not_allowed"
+	default:
+		return "script_injection__*_untrusted_contexts_not_allowed"
+	}
+}
+
+// FileType returns an integer that specifies the file types (action and/or workflow) the rule targets.
+func (r UntrustedContexts) FileType() int {
+	return rule.GetFileTypeRequired(r.FileTypeRequired)
+}
+
+// ParseConfig checks whether the given value is valid for this rule's configuration and returns it as bool.
+func (r UntrustedContexts) ParseConfig(conf interface{}) (bool, error) {
+	confValue, ok := conf.(bool)
+	if !ok {
+		return false, errValueNotBool
+	}
+
+	return confValue, nil
+}
+
+// Check runs a rule with the specified configuration on a dotgithub.File (action or workflow), reports any errors
+// to the reporter, and returns whether the file is compliant.
+func (r UntrustedContexts) Check(
+	ctx context.Context,
+	conf bool,
+	file dotgithub.File,
+	_ *dotgithub.DotGithub,
+	reporter rule.Reporter,
+) (bool, error) {
+	if !conf {
+		return true, nil
+	}
+
+	checker := &untrustedContextsChecker{reporter: reporter, compliant: true}
+
+	switch fileInstance := file.(type) {
+	case *action.Action:
+		if fileInstance.Runs == nil {
+			return true, nil
+		}
+
+		checker.positions = rule.NewPositions(fileInstance.Raw)
+		checker.checkSteps(ctx, "", fileInstance.Runs.Steps)
+	case *workflow.Workflow:
+		checker.positions = rule.NewPositions(fileInstance.Raw)
+		checker.untrustedInputs = getUntrustedInputs(fileInstance)
+
+		for _, jobName := range fileInstance.GetJobNames() {
+			checker.checkSteps(ctx, fmt.Sprintf("job '%s' ", jobName), fileInstance.Jobs[jobName].Steps)
+		}
+	default:
+		return false, errFileInvalidType
+	}
+
+	return checker.compliant, ctx.Err() //nolint:wrapcheck
+}
+
+type untrustedContextsChecker struct {
+	reporter rule.Reporter
+	// untrustedInputs contains normalized references to inputs that accept any text, eg. 'inputs.name'.
+	untrustedInputs []string
+	positions       *rule.Positions
+	compliant       bool
+}
+
+func (c *untrustedContextsChecker) checkSteps(ctx context.Context, msgPrefix string, steps []*step.Step) {
+	for stepIdx, stepInstance := range steps {
+		if ctx.Err() != nil {
+			return
+		}
+
+		c.checkScript(fmt.Sprintf("%sstep %d", msgPrefix, stepIdx+1), "run", stepInstance.Run, "\"$%s\"")
+
+		actionPath, _, _ := strings.Cut(stepInstance.Uses, "@")
+		if strings.EqualFold(actionPath, githubScriptAction) {
+			c.checkScript(
+				fmt.Sprintf("%sstep %d", msgPrefix, stepIdx+1),
+				"script",
+				stepInstance.GetWith("script"),
+				"process.env.%s",
+			)
+		}
+	}
+}
+
+// checkScript reports untrusted context references in expressions of the script. useFormat is the format of how
+// an environment variable is used in the script.
+func (c *untrustedContextsChecker) checkScript(stepName string, field string, script string, useFormat string) {
+	for _, line := range strings.Split(script, "\n") {
+		line = strings.TrimSpace(line)
+
+		locs := regexpExpression.FindAllStringIndex(line, -1)
+		if len(locs) == 0 {
+			continue
+		}
+
+		// lines are looked up instead of expressions, so that an expression in 'env' or 'if' is not mistaken
+		// for the one in the script
+		linePosition := c.positions.Next(line)
+
+		for _, loc := range locs {
+			position := linePosition
+			if position.Line > 0 {
+				position.Column += loc[0]
+			}
+
+			c.checkExpression(stepName, field, line[loc[0]:loc[1]], position, useFormat)
+		}
+	}
+}
+
+func (c *untrustedContextsChecker) checkExpression(
+	stepName string,
+	field string,
+	expression string,
+	position glitch.Position,
+	useFormat string,
+) {
+	for _, ref := range regexpContextRef.FindAllString(expression[3:len(expression)-2], -1) {
+		normalizedRef := normalizeContextRef(ref)
+		if !c.isUntrusted(normalizedRef) {
+			continue
+		}
+
+		envName := getEnvName(normalizedRef)
+
+		c.reporter.Report(rule.Diagnostic{
+			Message: fmt.Sprintf(
+				"%s uses untrusted '%s' in '%s', which allows script injection",
+				stepName,
+				ref,
+				field,
+			),
+			Position: position,
+			Fix: &glitch.Fix{
+				Message: fmt.Sprintf(
+					"move the value to 'env', eg. '%s: ${{ %s }}', and use %s in '%s'",
+					envName,
+					ref,
+					fmt.Sprintf(useFormat, envName),
+					field,
+				),
+			},
+		})
+
+		c.compliant = false
+	}
+}
+
+func (c *untrustedContextsChecker) isUntrusted(ref string) bool {
+	if slices.Contains(c.untrustedInputs, ref) {
+		return true
+	}
+
+	for _, regexpUntrusted := range regexpsUntrustedContext {
+		if regexpUntrusted.MatchString(ref) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getUntrustedInputs returns references to 'workflow_dispatch' inputs that accept any text.
+func getUntrustedInputs(workflowInstance *workflow.Workflow) []string {
+	if workflowInstance.On == nil || workflowInstance.On.WorkflowDispatch == nil {
+		return nil
+	}
+
+	refs := []string{}
+
+	for name, input := range workflowInstance.On.WorkflowDispatch.Inputs {
+		if input == nil || input.IsFreeText() {
+			name = strings.ToLower(name)
+			refs = append(refs, "inputs."+name, "github.event.inputs."+name)
+		}
+	}
+
+	return refs
+}
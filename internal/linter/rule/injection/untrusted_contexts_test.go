@@ -0,0 +1,142 @@
+package injection
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/linter/ruletest"
+)
+
+func TestUntrustedContextsParseConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := UntrustedContexts{}.ParseConfig(4)
+	if err == nil {
+		t.Errorf("UntrustedContexts.ParseConfig should return error when conf is not bool")
+	}
+
+	conf, err := UntrustedContexts{}.ParseConfig(true)
+	if err != nil || !conf {
+		t.Errorf("UntrustedContexts.ParseConfig should return the value when conf is bool")
+	}
+}
+
+func TestUntrustedContextsNotCompliant(t *testing.T) {
+	t.Parallel()
+
+	for _, fileTypeRequired := range []string{"action", "workflow"} {
+		checker, _ := rule.NewChecker(UntrustedContexts{FileTypeRequired: fileTypeRequired}, true)
+		d := ruletest.GetDotGithub()
+
+		numErrors := 2
+		expected := []string{"'github.event.pull_request.title' in 'run'", "'github.event.comment.body' in 'script'"}
+
+		if fileTypeRequired == "workflow" {
+			numErrors = 4
+			expected = []string{
+				"job 'main' step 1 uses untrusted 'github.head_ref' in 'run'",
+				"'github.event['commits'][0].message'",
+				"'inputs.message'",
+				"'github.event.inputs.message'",
+			}
+		}
+
+		fn := func(f dotgithub.File, n string) {
+			compliant, ruleErrors, err := ruletest.Check(2, checker, f, d)
+			if compliant {
+				t.Errorf("UntrustedContexts.Check on %s should return false when untrusted values are in scripts", n)
+			}
+
+			if err != nil {
+				t.Errorf("UntrustedContexts.Check on %s failed with an error: %s", n, err.Error())
+			}
+
+			joinedErrors := strings.Join(ruleErrors, "\n")
+			if len(ruleErrors) != numErrors {
+				t.Errorf("UntrustedContexts.Check on %s should report %d errors, got [%s]", n, numErrors, joinedErrors)
+			}
+
+			for _, e := range expected {
+				if !strings.Contains(joinedErrors, e) {
+					t.Errorf("UntrustedContexts.Check on %s should report %s, got [%s]", n, e, joinedErrors)
+				}
+			}
+		}
+
+		if fileTypeRequired == "action" {
+			ruletest.Action(d, "injection-untrusted-contexts", fn)
+		} else {
+			ruletest.Workflow(d, "injection-untrusted-contexts.yml", fn)
+		}
+	}
+}
+
+func TestUntrustedContextsPositions(t *testing.T) {
+	t.Parallel()
+
+	checker, _ := rule.NewChecker(UntrustedContexts{FileTypeRequired: "workflow"}, true)
+
+	d, err := dotgithub.NewFromFiles(context.Background(), map[string][]byte{
+		"workflows/main.yml": []byte(
+			"on: push\nenv:\n  REF: ${{ github.head_ref }}\njobs:\n" +
+				"  zeta:\n    runs-on: ubuntu-24.04\n    steps:\n" +
+				"      - if: ${{ github.head_ref }}\n        run: echo ${{ github.head_ref }}\n" +
+				"  alpha:\n    runs-on: ubuntu-24.04\n    steps:\n" +
+				"      - run: |\n          echo ${{ github.head_ref }}\n",
+		),
+	})
+	if err != nil {
+		t.Fatalf("NewFromFiles failed with an error: %s", err.Error())
+	}
+
+	diagnostics, err := ruletest.Diagnostics(2, checker, d.Workflows["main.yml"], d)
+
+	positions := make([][2]int, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		positions = append(positions, [2]int{diagnostic.Position.Line, diagnostic.Position.Column})
+	}
+
+	if err != nil || !slices.Equal(positions, [][2]int{{9, 19}, {14, 16}}) {
+		t.Errorf(
+			"UntrustedContexts.Check should report expressions in 'run' in the order of jobs in the file, got %v and %v",
+			positions,
+			err,
+		)
+	}
+}
+
+func TestNormalizeContextRef(t *testing.T) {
+	t.Parallel()
+
+	for ref, expected := range map[string]string{
+		"github.event['commits'][0].message":    "github.event.commits.*.message",
+		"GitHub.Event.Pull_Request.Title":       "github.event.pull_request.title",
+		"github.event.commits.0.author.email":   "github.event.commits.*.author.email",
+		`github.event["pages"][*]["page_name"]`: "github.event.pages.*.page_name",
+	} {
+		normalized := normalizeContextRef(ref)
+		if normalized != expected {
+			t.Errorf("normalizeContextRef(%s) should return %s, got %s", ref, expected, normalized)
+		}
+	}
+}
+
+func TestGetEnvName(t *testing.T) {
+	t.Parallel()
+
+	for ref, expected := range map[string]string{
+		"github.event.pull_request.title": "PULL_REQUEST_TITLE",
+		"github.head_ref":                 "GITHUB_HEAD_REF",
+		"github.event.commits.*.message":  "COMMITS_MESSAGE",
+		"inputs.release-name":             "INPUTS_RELEASE_NAME",
+	} {
+		envName := getEnvName(ref)
+		if envName != expected {
+			t.Errorf("getEnvName(%s) should return %s, got %s", ref, expected, envName)
+		}
+	}
+}
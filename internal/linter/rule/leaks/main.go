@@ -0,0 +1,96 @@
+// Package leaks contains rules checking for secrets that can leak, eg. through 'run' scripts, logs or environment
+// variables available to more steps than needed.
+package leaks
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	errValueNotBool    = errors.New("value should be bool")
+	errFileInvalidType = errors.New("file is of invalid type")
+)
+
+var (
+	regexpExpression = regexp.MustCompile(`\${{.*?}}`)
+	regexpSecretRef  = regexp.MustCompile(`\bsecrets\.[a-zA-Z0-9_\-]+`)
+	regexpInputRef   = regexp.MustCompile(`\binputs\.([a-zA-Z0-9_\-]+)`)
+	// regexpCredentialInput matches names of action inputs that are meant for secrets, eg. 'github-token'.
+	regexpCredentialInput = regexp.MustCompile(`(?i)(token|password|passwd|secret|api[_\-]?key|private[_\-]?key)`)
+	regexpEnvFile         = regexp.MustCompile(`>>?\s*["']?\$\{?(GITHUB_OUTPUT|GITHUB_ENV)\b`)
+	regexpPrint           = regexp.MustCompile(`(^|[;&|(]\s*)(echo|printf)\b`)
+	regexpEnvVarUse       = regexp.MustCompile(`\$\{?([a-zA-Z_][a-zA-Z0-9_]*)`)
+)
+
+// getSecretRefs returns references to secrets in expressions of the value, eg. 'secrets.TOKEN'. When isAction is
+// true, references to inputs named like credentials, eg. 'inputs.token', are returned as well, because a composite
+// action has no access to secrets and gets them through inputs.
+func getSecretRefs(value string, isAction bool) []string {
+	refs := []string{}
+
+	for _, expression := range regexpExpression.FindAllString(value, -1) {
+		refs = append(refs, regexpSecretRef.FindAllString(expression, -1)...)
+
+		if !isAction {
+			continue
+		}
+
+		for _, match := range regexpInputRef.FindAllStringSubmatch(expression, -1) {
+			if regexpCredentialInput.MatchString(match[1]) {
+				refs = append(refs, match[0])
+			}
+		}
+	}
+
+	return refs
+}
+
+// getSecretEnvs returns names of environment variables which values contain secrets, with the first reference to
+// a secret in the value.
+func getSecretEnvs(env map[string]string, isAction bool) map[string]string {
+	secretEnvs := map[string]string{}
+
+	for name, value := range env {
+		refs := getSecretRefs(value, isAction)
+		if len(refs) > 0 {
+			secretEnvs[name] = refs[0]
+		}
+	}
+
+	return secretEnvs
+}
+
+// getEnvFile returns the name of the environment file, ie. 'GITHUB_OUTPUT' or 'GITHUB_ENV', the script line writes
+// to, or an empty string.
+func getEnvFile(line string) string {
+	match := regexpEnvFile.FindStringSubmatch(line)
+	if match == nil {
+		return ""
+	}
+
+	return match[1]
+}
+
+// getUsedEnvs returns names of environment variables used in the script line, eg. 'TOKEN' for '$TOKEN' or '${TOKEN}'.
+func getUsedEnvs(line string) []string {
+	names := []string{}
+
+	for _, match := range regexpEnvVarUse.FindAllStringSubmatch(line, -1) {
+		if !slices.Contains(names, match[1]) {
+			names = append(names, match[1])
+		}
+	}
+
+	return names
+}
+
+// getEnvName returns a name of an environment variable for the referenced secret, eg. 'NPM_TOKEN' for
+// 'secrets.npm-token'.
+func getEnvName(ref string) string {
+	_, name, _ := strings.Cut(ref, ".")
+
+	return strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
@@ -0,0 +1,218 @@
+package leaks
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	"octo-linter/internal/action"
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/glitch"
+	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/step"
+	"octo-linter/internal/workflow"
+)
+
+// SecretsInRun checks whether 'run' scripts handle secrets safely. A secret must not be printed with 'echo' or
+// 'printf', expanded with '${{ }}' directly in the script instead of being passed through 'env', or written to
+// $GITHUB_OUTPUT or $GITHUB_ENV, from where it is available to other steps and jobs. In composite actions, inputs
+// named like credentials, eg. 'token', are treated as secrets.
+type SecretsInRun struct {
+	FileTypeRequired string
+}
+
+// ConfigName returns the name of the rule as defined in the configuration file.
+func (r SecretsInRun) ConfigName(t int) string {
+	switch t {
+	case rule.DotGithubFileTypeWorkflow:
+		return "leaking_secrets__workflow_run_not_allowed"
+	case rule.DotGithubFileTypeAction:
+		return "leaking_secrets__action_run_not_allowed"
+	default:
+		return "leaking_secrets__*_run_not_allowed"
+	}
+}
+
+// FileType returns an integer that specifies the file types (action and/or workflow) the rule targets.
+func (r SecretsInRun) FileType() int {
+	return rule.GetFileTypeRequired(r.FileTypeRequired)
+}
+
+// ParseConfig checks whether the given value is valid for this rule's configuration and returns it as bool.
+func (r SecretsInRun) ParseConfig(conf interface{}) (bool, error) {
+	confValue, ok := conf.(bool)
+	if !ok {
+		return false, errValueNotBool
+	}
+
+	return confValue, nil
+}
+
+// Check runs a rule with the specified configuration on a dotgithub.File (action or workflow), reports any errors
+// to the reporter, and returns whether the file is compliant.
+func (r SecretsInRun) Check(
+	ctx context.Context,
+	conf bool,
+	file dotgithub.File,
+	_ *dotgithub.DotGithub,
+	reporter rule.Reporter,
+) (bool, error) {
+	if !conf {
+		return true, nil
+	}
+
+	checker := &secretsInRunChecker{reporter: reporter, compliant: true}
+
+	switch fileInstance := file.(type) {
+	case *action.Action:
+		if fileInstance.Runs == nil {
+			return true, nil
+		}
+
+		checker.positions = rule.NewPositions(fileInstance.Raw)
+		checker.isAction = true
+		checker.checkSteps(ctx, "", nil, fileInstance.Runs.Steps)
+	case *workflow.Workflow:
+		checker.positions = rule.NewPositions(fileInstance.Raw)
+
+		for _, jobName := range fileInstance.GetJobNames() {
+			job := fileInstance.Jobs[jobName]
+
+			secretEnvs := getSecretEnvs(fileInstance.Env, false)
+			maps.Copy(secretEnvs, getSecretEnvs(job.Env, false))
+
+			checker.checkSteps(ctx, fmt.Sprintf("job '%s' ", jobName), secretEnvs, job.Steps)
+		}
+	default:
+		return false, errFileInvalidType
+	}
+
+	return checker.compliant, ctx.Err() //nolint:wrapcheck
+}
+
+type secretsInRunChecker struct {
+	reporter  rule.Reporter
+	positions *rule.Positions
+	isAction  bool
+	compliant bool
+}
+
+// checkSteps checks 'run' of each step. secretEnvs contains environment variables with secrets that are set for all
+// the steps, eg. in job's 'env'.
+func (c *secretsInRunChecker) checkSteps(
+	ctx context.Context,
+	msgPrefix string,
+	secretEnvs map[string]string,
+	steps []*step.Step,
+) {
+	for stepIdx, stepInstance := range steps {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if stepInstance.Run == "" {
+			continue
+		}
+
+		stepEnv := make(map[string]string, len(stepInstance.Env))
+		for name := range stepInstance.Env {
+			stepEnv[name] = stepInstance.GetEnv(name)
+		}
+
+		stepSecretEnvs := maps.Clone(secretEnvs)
+		if stepSecretEnvs == nil {
+			stepSecretEnvs = map[string]string{}
+		}
+
+		maps.Copy(stepSecretEnvs, getSecretEnvs(stepEnv, c.isAction))
+
+		for _, line := range strings.Split(stepInstance.Run, "\n") {
+			c.checkLine(fmt.Sprintf("%sstep %d", msgPrefix, stepIdx+1), line, stepSecretEnvs)
+		}
+	}
+}
+
+func (c *secretsInRunChecker) checkLine(stepName string, line string, secretEnvs map[string]string) {
+	// every line is looked up, so that repeated lines get their own positions
+	position := c.positions.Next(line)
+	envFile := getEnvFile(line)
+
+	for _, ref := range getSecretRefs(line, c.isAction) {
+		switch {
+		case envFile != "":
+			c.reportEnvFile(stepName, position, ref, "", envFile)
+		case regexpPrint.MatchString(line):
+			c.report(position, rule.Diagnostic{
+				Message: fmt.Sprintf("%s prints '%s' in 'run', which can reveal it in logs", stepName, ref),
+				Fix: &glitch.Fix{
+					Message: "remove the secret from the output",
+				},
+			})
+		default:
+			envName := getEnvName(ref)
+
+			c.report(position, rule.Diagnostic{
+				Message: fmt.Sprintf(
+					"%s expands '%s' in 'run', which writes it into the script file",
+					stepName,
+					ref,
+				),
+				Fix: &glitch.Fix{
+					Message: fmt.Sprintf(
+						"move the value to 'env', eg. '%s: ${{ %s }}', and use \"$%s\" in 'run'",
+						envName,
+						ref,
+						envName,
+					),
+				},
+			})
+		}
+	}
+
+	if envFile == "" {
+		return
+	}
+
+	for _, name := range getUsedEnvs(line) {
+		ref, ok := secretEnvs[name]
+		if ok {
+			c.reportEnvFile(stepName, position, ref, name, envFile)
+		}
+	}
+}
+
+// reportEnvFile reports a secret written to an environment file. envName is the name of the environment variable
+// the secret is written through, if any.
+func (c *secretsInRunChecker) reportEnvFile(
+	stepName string,
+	position glitch.Position,
+	ref string,
+	envName string,
+	envFile string,
+) {
+	through := ""
+	if envName != "" {
+		through = fmt.Sprintf(" through '$%s'", envName)
+	}
+
+	exposedTo := "all the following steps"
+	if envFile == "GITHUB_OUTPUT" {
+		exposedTo = "other steps and jobs as a plain output"
+	}
+
+	c.report(position, rule.Diagnostic{
+		Message: fmt.Sprintf("%s writes '%s'%s to $%s, which exposes it to %s", stepName, ref, through, envFile, exposedTo),
+		Fix: &glitch.Fix{
+			Message: fmt.Sprintf("pass '%s' to 'env' of the steps that need it instead", ref),
+		},
+	})
+}
+
+func (c *secretsInRunChecker) report(position glitch.Position, diagnostic rule.Diagnostic) {
+	diagnostic.Position = position
+
+	c.reporter.Report(diagnostic)
+
+	c.compliant = false
+}
@@ -0,0 +1,139 @@
+package leaks
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/linter/ruletest"
+)
+
+func TestSecretsInRunParseConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := SecretsInRun{}.ParseConfig(4)
+	if err == nil {
+		t.Errorf("SecretsInRun.ParseConfig should return error when conf is not bool")
+	}
+
+	conf, err := SecretsInRun{}.ParseConfig(true)
+	if err != nil || !conf {
+		t.Errorf("SecretsInRun.ParseConfig should return the value when conf is bool")
+	}
+}
+
+func TestSecretsInRunNotCompliant(t *testing.T) {
+	t.Parallel()
+
+	for _, fileTypeRequired := range []string{"action", "workflow"} {
+		checker, _ := rule.NewChecker(SecretsInRun{FileTypeRequired: fileTypeRequired}, true)
+		d := ruletest.GetDotGithub()
+
+		numErrors := 3
+		expected := []string{
+			"step 1 prints 'inputs.github-token' in 'run'",
+			"step 2 expands 'inputs.github-token' in 'run'",
+			"step 3 writes 'inputs.github-token' through '$GH_TOKEN' to $GITHUB_ENV",
+		}
+
+		if fileTypeRequired == "workflow" {
+			numErrors = 6
+			expected = []string{
+				"job 'build' step 1 prints 'secrets.API_KEY' in 'run'",
+				"job 'build' step 2 expands 'secrets.API_KEY' in 'run'",
+				"job 'build' step 3 writes 'secrets.API_KEY' to $GITHUB_OUTPUT",
+				"job 'build' step 3 writes 'secrets.NPM_TOKEN' through '$NPM_TOKEN' to $GITHUB_ENV",
+				"job 'build' step 4 writes 'secrets.API_KEY' through '$API_KEY' to $GITHUB_OUTPUT",
+				"job 'test' step 1 writes 'secrets.DEPLOY_TOKEN' through '$DEPLOY_TOKEN' to $GITHUB_ENV",
+			}
+		}
+
+		fn := func(f dotgithub.File, n string) {
+			compliant, ruleErrors, err := ruletest.Check(2, checker, f, d)
+			if compliant {
+				t.Errorf("SecretsInRun.Check on %s should return false when secrets leak from scripts", n)
+			}
+
+			if err != nil {
+				t.Errorf("SecretsInRun.Check on %s failed with an error: %s", n, err.Error())
+			}
+
+			joinedErrors := strings.Join(ruleErrors, "\n")
+			if len(ruleErrors) != numErrors {
+				t.Errorf("SecretsInRun.Check on %s should report %d errors, got [%s]", n, numErrors, joinedErrors)
+			}
+
+			for _, e := range expected {
+				if !strings.Contains(joinedErrors, e) {
+					t.Errorf("SecretsInRun.Check on %s should report %s, got [%s]", n, e, joinedErrors)
+				}
+			}
+		}
+
+		if fileTypeRequired == "action" {
+			ruletest.Action(d, "leaks-secrets-in-run", fn)
+		} else {
+			ruletest.Workflow(d, "leaks-secrets.yml", fn)
+		}
+	}
+}
+
+func TestSecretsInRunRepeatedLinePositions(t *testing.T) {
+	t.Parallel()
+
+	checker, _ := rule.NewChecker(SecretsInRun{FileTypeRequired: "workflow"}, true)
+
+	d, err := dotgithub.NewFromFiles(context.Background(), map[string][]byte{
+		"workflows/main.yml": []byte(
+			"on: push\njobs:\n  main:\n    runs-on: ubuntu-24.04\n    steps:\n" +
+				"      - run: echo \"${{ secrets.A }} ${{ secrets.B }}\"\n" +
+				"      - run: echo \"${{ secrets.A }} ${{ secrets.B }}\"\n",
+		),
+	})
+	if err != nil {
+		t.Fatalf("NewFromFiles failed with an error: %s", err.Error())
+	}
+
+	diagnostics, err := ruletest.Diagnostics(2, checker, d.Workflows["main.yml"], d)
+
+	lines := make([]int, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		lines = append(lines, diagnostic.Position.Line)
+	}
+
+	if err != nil || !slices.Equal(lines, []int{6, 6, 7, 7}) {
+		t.Errorf("SecretsInRun.Check should report a repeated line on each of its lines, got %v and %v", lines, err)
+	}
+}
+
+func TestSecretsInRunJobOrderPositions(t *testing.T) {
+	t.Parallel()
+
+	checker, _ := rule.NewChecker(SecretsInRun{FileTypeRequired: "workflow"}, true)
+
+	d, err := dotgithub.NewFromFiles(context.Background(), map[string][]byte{
+		"workflows/main.yml": []byte(
+			"on: push\njobs:\n" +
+				"  zeta:\n    runs-on: ubuntu-24.04\n    steps:\n      - run: echo \"${{ secrets.A }}\"\n" +
+				"  alpha:\n    runs-on: ubuntu-24.04\n    steps:\n      - run: echo \"${{ secrets.A }}\"\n",
+		),
+	})
+	if err != nil {
+		t.Fatalf("NewFromFiles failed with an error: %s", err.Error())
+	}
+
+	diagnostics, err := ruletest.Diagnostics(2, checker, d.Workflows["main.yml"], d)
+
+	positions := map[string]int{}
+	for _, diagnostic := range diagnostics {
+		jobName, _, _ := strings.Cut(diagnostic.Message, " step")
+		positions[jobName] = diagnostic.Position.Line
+	}
+
+	if err != nil || len(positions) != 2 || positions["job 'zeta'"] != 6 || positions["job 'alpha'"] != 10 {
+		t.Errorf("SecretsInRun.Check should report each job on its own line, got %v and %v", positions, err)
+	}
+}
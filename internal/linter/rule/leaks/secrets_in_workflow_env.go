@@ -0,0 +1,79 @@
+package leaks
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/glitch"
+	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/workflow"
+)
+
+// SecretsInWorkflowEnv checks whether workflow's 'env' does not contain secrets. Variables defined there are
+// available to every step of every job, including third-party actions, so a secret should be passed only to the
+// steps that need it.
+type SecretsInWorkflowEnv struct{}
+
+// ConfigName returns the name of the rule as defined in the configuration file.
+func (r SecretsInWorkflowEnv) ConfigName(int) string {
+	return "leaking_secrets__workflow_env_not_allowed"
+}
+
+// FileType returns an integer that specifies the file types (action and/or workflow) the rule targets.
+func (r SecretsInWorkflowEnv) FileType() int {
+	return rule.DotGithubFileTypeWorkflow
+}
+
+// ParseConfig checks whether the given value is valid for this rule's configuration and returns it as bool.
+func (r SecretsInWorkflowEnv) ParseConfig(conf interface{}) (bool, error) {
+	confValue, ok := conf.(bool)
+	if !ok {
+		return false, errValueNotBool
+	}
+
+	return confValue, nil
+}
+
+// Check runs a rule with the specified configuration on a workflow, reports any errors to the reporter, and returns
+// whether the file is compliant.
+func (r SecretsInWorkflowEnv) Check(
+	_ context.Context,
+	conf bool,
+	file dotgithub.File,
+	_ *dotgithub.DotGithub,
+	reporter rule.Reporter,
+) (bool, error) {
+	workflowInstance, ok := file.(*workflow.Workflow)
+	if !ok {
+		return false, errFileInvalidType
+	}
+
+	if !conf {
+		return true, nil
+	}
+
+	secretEnvs := getSecretEnvs(workflowInstance.Env, false)
+	positions := rule.NewPositions(workflowInstance.Raw)
+	compliant := true
+
+	for _, name := range slices.Sorted(maps.Keys(secretEnvs)) {
+		reporter.Report(rule.Diagnostic{
+			Message: fmt.Sprintf(
+				"workflow 'env' has '%s' with '%s', which exposes it to every step of every job",
+				name,
+				secretEnvs[name],
+			),
+			Position: positions.Next(name + ":"),
+			Fix: &glitch.Fix{
+				Message: fmt.Sprintf("move '%s' to 'env' of the steps that need it", name),
+			},
+		})
+
+		compliant = false
+	}
+
+	return compliant, nil
+}
@@ -0,0 +1,47 @@
+package leaks
+
+import (
+	"strings"
+	"testing"
+
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/linter/ruletest"
+)
+
+func TestSecretsInWorkflowEnvNotCompliant(t *testing.T) {
+	t.Parallel()
+
+	checker, _ := rule.NewChecker(SecretsInWorkflowEnv{}, true)
+	d := ruletest.GetDotGithub()
+
+	ruletest.Workflow(d, "leaks-secrets.yml", func(f dotgithub.File, n string) {
+		compliant, ruleErrors, err := ruletest.Check(2, checker, f, d)
+		if compliant {
+			t.Errorf("SecretsInWorkflowEnv.Check on %s should return false when workflow env has secrets", n)
+		}
+
+		if err != nil {
+			t.Errorf("SecretsInWorkflowEnv.Check on %s failed with an error: %s", n, err.Error())
+		}
+
+		joinedErrors := strings.Join(ruleErrors, "\n")
+		if len(ruleErrors) != 1 || !strings.Contains(joinedErrors, "'DEPLOY_TOKEN' with 'secrets.DEPLOY_TOKEN'") {
+			t.Errorf("SecretsInWorkflowEnv.Check on %s should report 'DEPLOY_TOKEN', got [%s]", n, joinedErrors)
+		}
+	})
+}
+
+func TestSecretsInWorkflowEnvCompliant(t *testing.T) {
+	t.Parallel()
+
+	checker, _ := rule.NewChecker(SecretsInWorkflowEnv{}, true)
+	d := ruletest.GetDotGithub()
+
+	ruletest.Workflow(d, "credentials-hardcoded.yml", func(f dotgithub.File, n string) {
+		compliant, ruleErrors, err := ruletest.Check(2, checker, f, d)
+		if !compliant || err != nil || len(ruleErrors) != 0 {
+			t.Errorf("SecretsInWorkflowEnv.Check on %s should return true when workflow env has no secrets", n)
+		}
+	})
+}
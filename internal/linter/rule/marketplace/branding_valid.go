@@ -0,0 +1,95 @@
+package marketplace
+
+import (
+	"fmt"
+
+	"octo-linter/internal/action"
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/glitch"
+	"octo-linter/internal/linter/rule"
+)
+
+// BrandingValid checks whether 'branding.icon' is one of the Feather icons and 'branding.color' is one of the
+// colours supported by GitHub Marketplace. Actions without 'branding' are skipped.
+type BrandingValid struct{}
+
+// ConfigName returns the name of the rule as defined in the configuration file.
+func (r BrandingValid) ConfigName(int) string {
+	return "marketplace__action_branding_valid"
+}
+
+// FileType returns an integer that specifies the file types (action and/or workflow) the rule targets.
+func (r BrandingValid) FileType() int {
+	return rule.DotGithubFileTypeAction
+}
+
+// Validate checks whether the given value is valid for this rule's configuration.
+func (r BrandingValid) Validate(conf interface{}) error {
+	_, ok := conf.(bool)
+	if !ok {
+		return errValueNotBool
+	}
+
+	return nil
+}
+
+// Lint runs a rule with the specified configuration on a dotgithub.File (action or workflow),
+// reports any errors via the given channel, and returns whether the file is compliant.
+func (r BrandingValid) Lint(
+	conf interface{},
+	file dotgithub.File,
+	_ *dotgithub.DotGithub,
+	chErrors chan<- glitch.Glitch,
+) (bool, error) {
+	confValue, confIsBool := conf.(bool)
+	if !confIsBool {
+		return false, errValueNotBool
+	}
+
+	if file.GetType() != rule.DotGithubFileTypeAction {
+		return true, nil
+	}
+
+	actionInstance, ok := file.(*action.Action)
+	if !ok {
+		return false, errFileInvalidType
+	}
+
+	if !confValue || actionInstance.Branding == nil {
+		return true, nil
+	}
+
+	compliant := true
+
+	if _, iconValid := brandingIcons[actionInstance.Branding.Icon]; !iconValid {
+		chErrors <- glitch.Glitch{
+			Path: actionInstance.Path,
+			Name: actionInstance.DirName,
+			Type: rule.DotGithubFileTypeAction,
+			ErrText: fmt.Sprintf(
+				"has 'branding.icon' set to '%s' which is not supported by GitHub Marketplace",
+				actionInstance.Branding.Icon,
+			),
+			RuleName: r.ConfigName(0),
+		}
+
+		compliant = false
+	}
+
+	if _, colorValid := brandingColors[actionInstance.Branding.Color]; !colorValid {
+		chErrors <- glitch.Glitch{
+			Path: actionInstance.Path,
+			Name: actionInstance.DirName,
+			Type: rule.DotGithubFileTypeAction,
+			ErrText: fmt.Sprintf(
+				"has 'branding.color' set to '%s' which is not supported by GitHub Marketplace",
+				actionInstance.Branding.Color,
+			),
+			RuleName: r.ConfigName(0),
+		}
+
+		compliant = false
+	}
+
+	return compliant, nil
+}
@@ -0,0 +1,90 @@
+package marketplace
+
+import (
+	"strings"
+	"testing"
+
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/ruletest"
+)
+
+func TestBrandingValidValidate(t *testing.T) {
+	t.Parallel()
+
+	rule := BrandingValid{}
+
+	confBad := 4
+
+	err := rule.Validate(confBad)
+	if err == nil {
+		t.Errorf("BrandingValid.Validate should return error when conf is not bool")
+	}
+
+	confGood := true
+
+	err = rule.Validate(confGood)
+	if err != nil {
+		t.Errorf("BrandingValid.Validate should not return error when conf is bool")
+	}
+}
+
+func TestBrandingValidNotCompliant(t *testing.T) {
+	t.Parallel()
+
+	rule := BrandingValid{}
+	conf := true
+	d := ruletest.GetDotGithub()
+
+	fn := func(f dotgithub.File, _ string) {
+		compliant, ruleErrors, err := ruletest.Lint(2, rule, conf, f, d)
+		if compliant {
+			t.Errorf("BrandingValid.Lint should return false when branding icon and color are not supported")
+		}
+
+		if err != nil {
+			t.Errorf("BrandingValid.Lint failed with an error: %s", err.Error())
+		}
+
+		if len(ruleErrors) != 2 {
+			t.Errorf(
+				"BrandingValid.Lint should send 2 errors over the channel, got [%s]",
+				strings.Join(ruleErrors, "\n"),
+			)
+		}
+	}
+
+	ruletest.Action(d, "marketplace-branding-valid", fn)
+}
+
+func TestBrandingValidCompliant(t *testing.T) {
+	t.Parallel()
+
+	rule := BrandingValid{}
+	conf := true
+	d := ruletest.GetDotGithub()
+
+	if d.GetAction(dotgithub.RootActionName) == nil {
+		t.Fatalf("root action should be loaded into DotGithub")
+	}
+
+	fn := func(f dotgithub.File, _ string) {
+		compliant, ruleErrors, err := ruletest.Lint(2, rule, conf, f, d)
+		if !compliant {
+			t.Errorf("BrandingValid.Lint should return true when branding is valid or not defined")
+		}
+
+		if err != nil {
+			t.Errorf("BrandingValid.Lint failed with an error: %s", err.Error())
+		}
+
+		if len(ruleErrors) > 0 {
+			t.Errorf(
+				"BrandingValid.Lint should not send any error over the channel, sent %s",
+				strings.Join(ruleErrors, "|"),
+			)
+		}
+	}
+
+	ruletest.Action(d, dotgithub.RootActionName, fn)
+	ruletest.Action(d, "valid-action", fn)
+}
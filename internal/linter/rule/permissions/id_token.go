@@ -0,0 +1,101 @@
+package permissions
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/workflow"
+)
+
+const scopeIDToken = "id-token"
+
+// IDTokenWriteRequiresOIDCAction checks whether jobs that can request an OIDC token, ie. have 'id-token: write',
+// use one of the configured actions that exchange the token, eg. 'aws-actions/configure-aws-credentials', or
+// request the token in a 'run' script. Jobs calling a reusable workflow are skipped, as the token may be used there.
+type IDTokenWriteRequiresOIDCAction struct{}
+
+// ConfigName returns the name of the rule as defined in the configuration file.
+func (r IDTokenWriteRequiresOIDCAction) ConfigName(int) string {
+	return "permissions__workflow_id_token_write_requires_oidc_action"
+}
+
+// FileType returns an integer that specifies the file types (action and/or workflow) the rule targets.
+func (r IDTokenWriteRequiresOIDCAction) FileType() int {
+	return rule.DotGithubFileTypeWorkflow
+}
+
+// ParseConfig checks whether the given value is valid for this rule's configuration and returns the actions,
+// without a ref, eg. 'google-github-actions/auth'.
+func (r IDTokenWriteRequiresOIDCAction) ParseConfig(conf interface{}) ([]string, error) {
+	return parseStringArray(conf)
+}
+
+// Check runs a rule with the specified configuration on a workflow, reports any errors to the reporter, and returns
+// whether the file is compliant.
+func (r IDTokenWriteRequiresOIDCAction) Check(
+	_ context.Context,
+	conf []string,
+	file dotgithub.File,
+	_ *dotgithub.DotGithub,
+	reporter rule.Reporter,
+) (bool, error) {
+	workflowInstance, ok := file.(*workflow.Workflow)
+	if !ok {
+		return false, errFileInvalidType
+	}
+
+	compliant := true
+
+	for _, jobName := range getSortedJobNames(workflowInstance) {
+		job := workflowInstance.Jobs[jobName]
+
+		permissions, inherited := getJobPermissions(workflowInstance, job)
+		if permissions == nil || permissions.GetScope(scopeIDToken) != workflow.PermissionWrite ||
+			job.IsCallingWorkflow() || r.isUsingOIDC(conf, job) {
+			continue
+		}
+
+		compliant = false
+
+		grantedBy := "its 'permissions'"
+		if inherited {
+			grantedBy = "workflow 'permissions'"
+		}
+
+		reporter.Report(rule.Diagnostic{
+			Message: fmt.Sprintf(
+				"job '%s' gets 'id-token: write' from %s but does not use any action that requires OIDC token",
+				jobName,
+				grantedBy,
+			),
+		})
+	}
+
+	return compliant, nil
+}
+
+func (r IDTokenWriteRequiresOIDCAction) isUsingOIDC(oidcActions []string, job *workflow.Job) bool {
+	for _, step := range job.Steps {
+		if strings.Contains(step.Run, "ACTIONS_ID_TOKEN_REQUEST_") {
+			return true
+		}
+
+		actionPath, _, _ := strings.Cut(step.Uses, "@")
+		if actionPath == "" {
+			continue
+		}
+
+		if slices.ContainsFunc(oidcActions, func(oidcAction string) bool {
+			return strings.EqualFold(actionPath, oidcAction) ||
+				strings.HasPrefix(strings.ToLower(actionPath), strings.ToLower(oidcAction)+"/")
+		}) {
+			return true
+		}
+	}
+
+	return false
+}
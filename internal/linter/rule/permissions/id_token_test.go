@@ -0,0 +1,31 @@
+package permissions
+
+import (
+	"testing"
+
+	"octo-linter/internal/linter/rule"
+)
+
+func TestIDTokenWriteRequiresOIDCActionParseConfig(t *testing.T) {
+	t.Parallel()
+
+	for _, confBad := range []interface{}{4, "google-github-actions/auth", []interface{}{4}} {
+		_, err := IDTokenWriteRequiresOIDCAction{}.ParseConfig(confBad)
+		if err == nil {
+			t.Errorf("IDTokenWriteRequiresOIDCAction.ParseConfig should return error when conf is %v", confBad)
+		}
+	}
+}
+
+func TestIDTokenWriteRequiresOIDCAction(t *testing.T) {
+	t.Parallel()
+
+	checker, _ := rule.NewChecker(IDTokenWriteRequiresOIDCAction{}, []interface{}{"google-github-actions/auth"})
+
+	checkWorkflow(t, checker, "permissions-not-compliant.yml", 2)
+	checkWorkflow(t, checker, "permissions-compliant.yml", 0)
+
+	checker, _ = rule.NewChecker(IDTokenWriteRequiresOIDCAction{}, []interface{}{"aws-actions/configure-aws-credentials"})
+
+	checkWorkflow(t, checker, "permissions-compliant.yml", 1)
+}
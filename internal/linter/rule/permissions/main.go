@@ -0,0 +1,75 @@
+// Package permissions contains rules checking the 'permissions' of the GITHUB_TOKEN granted to workflows and jobs.
+package permissions
+
+import (
+	"errors"
+	"maps"
+	"slices"
+
+	"octo-linter/internal/workflow"
+)
+
+var (
+	errValueNotBool        = errors.New("value should be bool")
+	errValueNotStringArray = errors.New("value should be []string")
+	errValueNotShorthand   = errors.New("value can contain only 'read-all' and/or 'write-all'")
+	errValueNotScope       = errors.New("value can contain only permission scopes, eg. 'contents'")
+	errFileInvalidType     = errors.New("file is of invalid type")
+)
+
+// scopes contains the scopes that can be set in the 'permissions' field.
+//
+//nolint:gochecknoglobals
+var scopes = []string{
+	"actions",
+	"attestations",
+	"checks",
+	"contents",
+	"deployments",
+	"discussions",
+	"id-token",
+	"issues",
+	"models",
+	"packages",
+	"pages",
+	"pull-requests",
+	"repository-projects",
+	"security-events",
+	"statuses",
+}
+
+func parseStringArray(conf interface{}) ([]string, error) {
+	vals, ok := conf.([]interface{})
+	if !ok {
+		return nil, errValueNotStringArray
+	}
+
+	strs := make([]string, 0, len(vals))
+
+	for _, v := range vals {
+		str, ok := v.(string)
+		if !ok {
+			return nil, errValueNotStringArray
+		}
+
+		strs = append(strs, str)
+	}
+
+	return strs, nil
+}
+
+// getSortedJobNames returns names of the workflow jobs so that they are reported always in the same order.
+func getSortedJobNames(workflowInstance *workflow.Workflow) []string {
+	return slices.Sorted(maps.Keys(workflowInstance.Jobs))
+}
+
+// getJobPermissions returns the permissions the job runs with, and whether they are inherited from the workflow. It
+// returns nil when neither the job nor the workflow has 'permissions'.
+func getJobPermissions(workflowInstance *workflow.Workflow, job *workflow.Job) (*workflow.Permissions, bool) {
+	permissions := job.GetPermissions()
+	if permissions != nil {
+		return permissions, false
+	}
+
+	return workflowInstance.GetPermissions(), true
+}
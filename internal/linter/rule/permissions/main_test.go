@@ -0,0 +1,47 @@
+package permissions
+
+import (
+	"strings"
+	"testing"
+
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/linter/ruletest"
+)
+
+// checkWorkflow runs checker on a test workflow and checks whether it reports the expected number of errors.
+func checkWorkflow(t *testing.T, checker rule.Checker, workflowName string, numErrors int) {
+	t.Helper()
+
+	d := ruletest.GetDotGithub()
+	ran := false
+
+	fn := func(f dotgithub.File, n string) {
+		ran = true
+
+		compliant, ruleErrors, err := ruletest.Check(2, checker, f, d)
+		if compliant != (numErrors == 0) {
+			t.Errorf("%s.Check on %s should return %v", checker.ConfigName(0), n, numErrors == 0)
+		}
+
+		if err != nil {
+			t.Errorf("%s.Check on %s failed with an error: %s", checker.ConfigName(0), n, err.Error())
+		}
+
+		if len(ruleErrors) != numErrors {
+			t.Errorf(
+				"%s.Check on %s should report %d errors, got [%s]",
+				checker.ConfigName(0),
+				n,
+				numErrors,
+				strings.Join(ruleErrors, "\n"),
+			)
+		}
+	}
+
+	ruletest.Workflow(d, workflowName, fn)
+
+	if !ran {
+		t.Fatalf("workflow %s not found", workflowName)
+	}
+}
@@ -0,0 +1,72 @@
+package permissions
+
+import (
+	"context"
+	"fmt"
+
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/workflow"
+)
+
+// Required checks whether 'permissions' are set at the workflow level or in every job. Without them, jobs get the
+// default permissions of the repository, which are often write access to all the scopes.
+type Required struct{}
+
+// ConfigName returns the name of the rule as defined in the configuration file.
+func (r Required) ConfigName(int) string {
+	return "permissions__workflow_requires_permissions"
+}
+
+// FileType returns an integer that specifies the file types (action and/or workflow) the rule targets.
+func (r Required) FileType() int {
+	return rule.DotGithubFileTypeWorkflow
+}
+
+// ParseConfig checks whether the given value is valid for this rule's configuration and returns it as bool.
+func (r Required) ParseConfig(conf interface{}) (bool, error) {
+	confValue, ok := conf.(bool)
+	if !ok {
+		return false, errValueNotBool
+	}
+
+	return confValue, nil
+}
+
+// Check runs a rule with the specified configuration on a workflow, reports any errors to the reporter, and returns
+// whether the file is compliant.
+func (r Required) Check(
+	_ context.Context,
+	conf bool,
+	file dotgithub.File,
+	_ *dotgithub.DotGithub,
+	reporter rule.Reporter,
+) (bool, error) {
+	workflowInstance, ok := file.(*workflow.Workflow)
+	if !ok {
+		return false, errFileInvalidType
+	}
+
+	if !conf || workflowInstance.GetPermissions() != nil {
+		return true, nil
+	}
+
+	compliant := true
+
+	for _, jobName := range getSortedJobNames(workflowInstance) {
+		if workflowInstance.Jobs[jobName].GetPermissions() != nil {
+			continue
+		}
+
+		compliant = false
+
+		reporter.Report(rule.Diagnostic{
+			Message: fmt.Sprintf(
+				"job '%s' does not have 'permissions' and neither does the workflow, so it gets the repository default",
+				jobName,
+			),
+		})
+	}
+
+	return compliant, nil
+}
@@ -0,0 +1,31 @@
+package permissions
+
+import (
+	"testing"
+
+	"octo-linter/internal/linter/rule"
+)
+
+func TestRequiredParseConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := Required{}.ParseConfig(4)
+	if err == nil {
+		t.Errorf("Required.ParseConfig should return error when conf is not bool")
+	}
+
+	conf, err := Required{}.ParseConfig(true)
+	if err != nil || !conf {
+		t.Errorf("Required.ParseConfig should return the value when conf is bool")
+	}
+}
+
+func TestRequired(t *testing.T) {
+	t.Parallel()
+
+	checker, _ := rule.NewChecker(Required{}, true)
+
+	checkWorkflow(t, checker, "permissions-required.yml", 1)
+	checkWorkflow(t, checker, "permissions-not-compliant.yml", 0)
+	checkWorkflow(t, checker, "permissions-compliant.yml", 0)
+}
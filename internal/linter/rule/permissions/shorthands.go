@@ -0,0 +1,90 @@
+package permissions
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/workflow"
+)
+
+// ShorthandsNotAllowed checks whether 'permissions' of the workflow and its jobs do not use any of the configured
+// shorthands, ie. 'read-all' or 'write-all', instead of listing the scopes.
+type ShorthandsNotAllowed struct{}
+
+// ConfigName returns the name of the rule as defined in the configuration file.
+func (r ShorthandsNotAllowed) ConfigName(int) string {
+	return "permissions__workflow_shorthands_not_allowed"
+}
+
+// FileType returns an integer that specifies the file types (action and/or workflow) the rule targets.
+func (r ShorthandsNotAllowed) FileType() int {
+	return rule.DotGithubFileTypeWorkflow
+}
+
+// ParseConfig checks whether the given value is valid for this rule's configuration and returns the shorthands.
+func (r ShorthandsNotAllowed) ParseConfig(conf interface{}) ([]string, error) {
+	shorthands, err := parseStringArray(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, shorthand := range shorthands {
+		if shorthand != workflow.PermissionsReadAll && shorthand != workflow.PermissionsWriteAll {
+			return nil, errValueNotShorthand
+		}
+	}
+
+	return shorthands, nil
+}
+
+// Check runs a rule with the specified configuration on a workflow, reports any errors to the reporter, and returns
+// whether the file is compliant.
+func (r ShorthandsNotAllowed) Check(
+	_ context.Context,
+	conf []string,
+	file dotgithub.File,
+	_ *dotgithub.DotGithub,
+	reporter rule.Reporter,
+) (bool, error) {
+	workflowInstance, ok := file.(*workflow.Workflow)
+	if !ok {
+		return false, errFileInvalidType
+	}
+
+	if len(conf) == 0 {
+		return true, nil
+	}
+
+	compliant := true
+
+	permissions := workflowInstance.GetPermissions()
+	if permissions != nil && slices.Contains(conf, permissions.Shorthand) {
+		compliant = false
+
+		reporter.Report(rule.Diagnostic{
+			Message: fmt.Sprintf("workflow 'permissions' should list scopes instead of '%s'", permissions.Shorthand),
+		})
+	}
+
+	for _, jobName := range getSortedJobNames(workflowInstance) {
+		permissions := workflowInstance.Jobs[jobName].GetPermissions()
+		if permissions == nil || !slices.Contains(conf, permissions.Shorthand) {
+			continue
+		}
+
+		compliant = false
+
+		reporter.Report(rule.Diagnostic{
+			Message: fmt.Sprintf(
+				"job '%s' 'permissions' should list scopes instead of '%s'",
+				jobName,
+				permissions.Shorthand,
+			),
+		})
+	}
+
+	return compliant, nil
+}
@@ -0,0 +1,36 @@
+package permissions
+
+import (
+	"testing"
+
+	"octo-linter/internal/linter/rule"
+)
+
+func TestShorthandsNotAllowedParseConfig(t *testing.T) {
+	t.Parallel()
+
+	for _, confBad := range []interface{}{4, "write-all", []interface{}{"write-all", "none"}} {
+		_, err := ShorthandsNotAllowed{}.ParseConfig(confBad)
+		if err == nil {
+			t.Errorf("ShorthandsNotAllowed.ParseConfig should return error when conf is %v", confBad)
+		}
+	}
+
+	_, err := ShorthandsNotAllowed{}.ParseConfig([]interface{}{"read-all", "write-all"})
+	if err != nil {
+		t.Errorf("ShorthandsNotAllowed.ParseConfig should not return error when conf contains shorthands")
+	}
+}
+
+func TestShorthandsNotAllowed(t *testing.T) {
+	t.Parallel()
+
+	checker, _ := rule.NewChecker(ShorthandsNotAllowed{}, []interface{}{"read-all", "write-all"})
+
+	checkWorkflow(t, checker, "permissions-not-compliant.yml", 2)
+	checkWorkflow(t, checker, "permissions-compliant.yml", 0)
+
+	checker, _ = rule.NewChecker(ShorthandsNotAllowed{}, []interface{}{"write-all"})
+
+	checkWorkflow(t, checker, "permissions-not-compliant.yml", 1)
+}
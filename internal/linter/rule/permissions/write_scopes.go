@@ -0,0 +1,112 @@
+package permissions
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/workflow"
+)
+
+// WriteScopesAllowed checks whether 'permissions' of the workflow and its jobs grant 'write' access only to the
+// configured scopes. The 'write-all' shorthand grants it to every scope.
+type WriteScopesAllowed struct{}
+
+// ConfigName returns the name of the rule as defined in the configuration file.
+func (r WriteScopesAllowed) ConfigName(int) string {
+	return "permissions__workflow_write_scopes_allowed"
+}
+
+// FileType returns an integer that specifies the file types (action and/or workflow) the rule targets.
+func (r WriteScopesAllowed) FileType() int {
+	return rule.DotGithubFileTypeWorkflow
+}
+
+// ParseConfig checks whether the given value is valid for this rule's configuration and returns the scopes.
+func (r WriteScopesAllowed) ParseConfig(conf interface{}) ([]string, error) {
+	allowedScopes, err := parseStringArray(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, scope := range allowedScopes {
+		if !slices.Contains(scopes, scope) {
+			return nil, errValueNotScope
+		}
+	}
+
+	return allowedScopes, nil
+}
+
+// Check runs a rule with the specified configuration on a workflow, reports any errors to the reporter, and returns
+// whether the file is compliant.
+func (r WriteScopesAllowed) Check(
+	_ context.Context,
+	conf []string,
+	file dotgithub.File,
+	_ *dotgithub.DotGithub,
+	reporter rule.Reporter,
+) (bool, error) {
+	workflowInstance, ok := file.(*workflow.Workflow)
+	if !ok {
+		return false, errFileInvalidType
+	}
+
+	compliant := true
+
+	notAllowed := r.getNotAllowedWriteScopes(conf, workflowInstance.GetPermissions())
+	if len(notAllowed) > 0 {
+		compliant = false
+
+		reporter.Report(rule.Diagnostic{
+			Message: fmt.Sprintf(
+				"workflow 'permissions' grant 'write' to scopes that are not allowed: %s",
+				strings.Join(notAllowed, ", "),
+			),
+		})
+	}
+
+	for _, jobName := range getSortedJobNames(workflowInstance) {
+		notAllowed := r.getNotAllowedWriteScopes(conf, workflowInstance.Jobs[jobName].GetPermissions())
+		if len(notAllowed) == 0 {
+			continue
+		}
+
+		compliant = false
+
+		reporter.Report(rule.Diagnostic{
+			Message: fmt.Sprintf(
+				"job '%s' 'permissions' grant 'write' to scopes that are not allowed: %s",
+				jobName,
+				strings.Join(notAllowed, ", "),
+			),
+		})
+	}
+
+	return compliant, nil
+}
+
+func (r WriteScopesAllowed) getNotAllowedWriteScopes(allowed []string, permissions *workflow.Permissions) []string {
+	if permissions == nil {
+		return nil
+	}
+
+	scopesToCheck := scopes
+	if !permissions.IsWriteAll() {
+		scopesToCheck = slices.Sorted(maps.Keys(permissions.Scopes))
+	}
+
+	notAllowed := []string{}
+
+	for _, scope := range scopesToCheck {
+		if permissions.GetScope(scope) == workflow.PermissionWrite && !slices.Contains(allowed, scope) {
+			notAllowed = append(notAllowed, scope)
+		}
+	}
+
+	return notAllowed
+}
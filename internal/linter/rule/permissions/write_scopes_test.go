@@ -0,0 +1,57 @@
+package permissions
+
+import (
+	"testing"
+
+	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/workflow"
+)
+
+func TestWriteScopesAllowedParseConfig(t *testing.T) {
+	t.Parallel()
+
+	for _, confBad := range []interface{}{4, "contents", []interface{}{"contents", "unknown"}} {
+		_, err := WriteScopesAllowed{}.ParseConfig(confBad)
+		if err == nil {
+			t.Errorf("WriteScopesAllowed.ParseConfig should return error when conf is %v", confBad)
+		}
+	}
+
+	_, err := WriteScopesAllowed{}.ParseConfig([]interface{}{"contents", "id-token"})
+	if err != nil {
+		t.Errorf("WriteScopesAllowed.ParseConfig should not return error when conf contains scopes")
+	}
+}
+
+func TestWriteScopesAllowed(t *testing.T) {
+	t.Parallel()
+
+	checker, _ := rule.NewChecker(WriteScopesAllowed{}, []interface{}{"contents"})
+
+	checkWorkflow(t, checker, "permissions-not-compliant.yml", 2)
+	checkWorkflow(t, checker, "permissions-compliant.yml", 2)
+
+	checker, _ = rule.NewChecker(WriteScopesAllowed{}, []interface{}{"contents", "id-token"})
+
+	checkWorkflow(t, checker, "permissions-compliant.yml", 0)
+}
+
+func TestGetNotAllowedWriteScopes(t *testing.T) {
+	t.Parallel()
+
+	notAllowed := WriteScopesAllowed{}.getNotAllowedWriteScopes(
+		[]string{"contents"},
+		&workflow.Permissions{Scopes: map[string]string{"packages": "write", "contents": "write", "issues": "read"}},
+	)
+	if len(notAllowed) != 1 || notAllowed[0] != "packages" {
+		t.Errorf("getNotAllowedWriteScopes should return only scopes with write that are not allowed, got %v", notAllowed)
+	}
+
+	notAllowed = WriteScopesAllowed{}.getNotAllowedWriteScopes(
+		[]string{"contents"},
+		&workflow.Permissions{Shorthand: workflow.PermissionsWriteAll},
+	)
+	if len(notAllowed) != len(scopes)-1 {
+		t.Errorf("getNotAllowedWriteScopes should return all the other scopes for 'write-all', got %v", notAllowed)
+	}
+}
@@ -0,0 +1,46 @@
+package rule
+
+import (
+	"bytes"
+	"strings"
+
+	"octo-linter/internal/linter/glitch"
+)
+
+// Positions finds positions of texts, eg. lines of a 'run' script, in a raw file. Each text is looked for after its
+// previous occurrence, so that identical texts that are looked up in the order they appear in the file get their
+// own positions instead of the position of the first one.
+type Positions struct {
+	raw     []byte
+	offsets map[string]int
+}
+
+// NewPositions returns Positions of texts in the raw file.
+func NewPositions(raw []byte) *Positions {
+	return &Positions{raw: raw, offsets: map[string]int{}}
+}
+
+// Next returns the position of the next occurrence of the text, without leading and trailing white space, in the
+// file. It returns a zero position when the text is empty or has no more occurrences, eg. because it is quoted with
+// escape sequences.
+func (p *Positions) Next(text string) glitch.Position {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return glitch.Position{}
+	}
+
+	offset := p.offsets[text]
+
+	idx := bytes.Index(p.raw[offset:], []byte(text))
+	if idx == -1 {
+		return glitch.Position{}
+	}
+
+	idx += offset
+	p.offsets[text] = idx + len(text)
+
+	return glitch.Position{
+		Line:   bytes.Count(p.raw[:idx], []byte("\n")) + 1,
+		Column: idx - bytes.LastIndexByte(p.raw[:idx], '\n'),
+	}
+}
@@ -0,0 +1,34 @@
+package rule
+
+import (
+	"testing"
+
+	"octo-linter/internal/linter/glitch"
+)
+
+func TestPositionsNext(t *testing.T) {
+	t.Parallel()
+
+	positions := NewPositions([]byte("run: |\n  echo 1\n  make\n  echo 1\nenv:\n  A: make\n"))
+
+	tests := []struct {
+		text     string
+		expected glitch.Position
+	}{
+		{text: "  echo 1", expected: glitch.Position{Line: 2, Column: 3}},
+		{text: "make", expected: glitch.Position{Line: 3, Column: 3}},
+		{text: "echo 1 ", expected: glitch.Position{Line: 4, Column: 3}},
+		{text: "echo 1", expected: glitch.Position{}},
+		{text: "make", expected: glitch.Position{Line: 6, Column: 6}},
+		{text: "run: |", expected: glitch.Position{Line: 1, Column: 1}},
+		{text: " ", expected: glitch.Position{}},
+		{text: "missing", expected: glitch.Position{}},
+	}
+
+	for _, tt := range tests {
+		position := positions.Next(tt.text)
+		if position != tt.expected {
+			t.Errorf("Positions.Next(%q) should return %+v, got %+v", tt.text, tt.expected, position)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Package remotecode contains rules checking for scripts that run code downloaded from the internet without
+// verifying it.
+package remotecode
+
+import (
+	"errors"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var (
+	errValueNotBoolOrMap   = errors.New("value should be bool or map with 'trusted_url_prefixes' key")
+	errValueNotStringArray = errors.New("value should be []string")
+	errFileInvalidType     = errors.New("file is of invalid type")
+)
+
+const (
+	regexpDownloader  = `(curl|wget|Invoke-WebRequest|Invoke-RestMethod|iwr|irm)`
+	regexpInterpreter = `(bash|sh|zsh|dash|ksh|python[0-9.]*|perl|ruby|node|pwsh|powershell|iex|Invoke-Expression)`
+)
+
+var (
+	// regexpPipeToInterpreter matches remote content piped into an interpreter, eg. 'curl -sL URL | sudo bash -s'.
+	regexpPipeToInterpreter = regexp.MustCompile(
+		`(?i)\b` + regexpDownloader + `\b[^|;&]*\|\s*(sudo\s+(-\S+\s+)*)?` + regexpInterpreter + `\b`,
+	)
+	// regexpInterpreterSubstitution matches remote content run with process or command substitution, eg.
+	// 'bash <(curl URL)' or 'sh -c "$(wget -O- URL)"'.
+	regexpInterpreterSubstitution = regexp.MustCompile(
+		`(?i)\b` + regexpInterpreter + `\b.*(<\(|\$\()\s*` + regexpDownloader + `\b`,
+	)
+	regexpDownload = regexp.MustCompile(`(?i)(^|[;&|(]\s*)` + regexpDownloader + `\b`)
+	regexpChmodX   = regexp.MustCompile(`(^|[;&|(]\s*)chmod\s+(-\S+\s+)*([ugoa]*\+[rw]*x[rw]*|[0-7]?[1357][0-7]{2})\s+(\S+)`)
+	regexpChecksum = regexp.MustCompile(
+		`(?i)\b(sha(1|224|256|384|512)sum|shasum|md5sum|b2sum|gpg\s+(--verify|-v)|cosign\s+verify|` +
+			`slsa-verifier|gh\s+attestation\s+verify|Get-FileHash|openssl\s+dgst)\b`,
+	)
+	regexpSudo = regexp.MustCompile(`(^|[;&|(]\s*)sudo\b`)
+	regexpURL  = regexp.MustCompile(`https?://[^\s'"|;&)]+`)
+)
+
+// scriptLine is a logical line of a script, with lines ending with a backslash joined.
+type scriptLine struct {
+	text string
+	// firstLine is the first physical line, which is used to find the line in the file.
+	firstLine string
+}
+
+// getScriptLines returns logical lines of the script.
+func getScriptLines(script string) []scriptLine {
+	lines := []scriptLine{}
+
+	var current *scriptLine
+
+	for _, line := range strings.Split(script, "\n") {
+		if current == nil {
+			current = &scriptLine{firstLine: line}
+		}
+
+		trimmed := strings.TrimRight(line, " \t")
+		if strings.HasSuffix(trimmed, "\\") {
+			current.text += strings.TrimSuffix(trimmed, "\\") + " "
+
+			continue
+		}
+
+		current.text += line
+		lines = append(lines, *current)
+		current = nil
+	}
+
+	if current != nil {
+		lines = append(lines, *current)
+	}
+
+	return lines
+}
+
+// isTrusted checks whether all the URLs in the line start with one of the trusted prefixes. A line without URLs, eg.
+// when the URL is in a variable, is not trusted.
+func isTrusted(line string, trustedURLPrefixes []string) bool {
+	urls := regexpURL.FindAllString(line, -1)
+	if len(urls) == 0 {
+		return false
+	}
+
+	for _, url := range urls {
+		trusted := false
+
+		for _, prefix := range trustedURLPrefixes {
+			if strings.HasPrefix(url, prefix) {
+				trusted = true
+
+				break
+			}
+		}
+
+		if !trusted {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isDownloadedFile checks whether the file is saved by one of the download lines, by its name or, when the remote
+// name is used, eg. with 'curl -O', by the last element of the URL.
+func isDownloadedFile(file string, downloadLines []string) bool {
+	name := path.Base(strings.Trim(file, `"'`))
+
+	for _, line := range downloadLines {
+		if strings.Contains(line, name) {
+			return true
+		}
+	}
+
+	return false
+}
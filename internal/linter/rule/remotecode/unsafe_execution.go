@@ -0,0 +1,201 @@
+package remotecode
+
+import (
+	"context"
+	"fmt"
+
+	"octo-linter/internal/action"
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/glitch"
+	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/step"
+	"octo-linter/internal/workflow"
+)
+
+// UnsafeExecutionConfig is the configuration of UnsafeExecution.
+type UnsafeExecutionConfig struct {
+	Enabled bool
+	// TrustedURLPrefixes contains prefixes of URLs which content can be run without verification, eg.
+	// 'https://raw.githubusercontent.com/my-org/'.
+	TrustedURLPrefixes []string
+}
+
+// UnsafeExecution checks whether 'run' scripts do not run remote code without verifying it, ie. do not pipe
+// downloaded content into an interpreter, eg. 'curl URL | bash', and do not make a downloaded file executable with
+// 'chmod +x' when its checksum or signature is not verified in the script. Use of 'sudo' is reported as a warning.
+type UnsafeExecution struct {
+	FileTypeRequired string
+}
+
+// ConfigName returns the name of the rule as defined in the configuration file.
+func (r UnsafeExecution) ConfigName(t int) string {
+	switch t {
+	case rule.DotGithubFileTypeWorkflow:
+		return "remote_scripts__workflow_unsafe_execution_not_allowed"
+	case rule.DotGithubFileTypeAction:
+		return "remote_scripts__action_unsafe_execution_not_allowed"
+	default:
+		return "remote_scripts__*_unsafe_execution_not_allowed"
+	}
+}
+
+// FileType returns an integer that specifies the file types (action and/or workflow) the rule targets.
+func (r UnsafeExecution) FileType() int {
+	return rule.GetFileTypeRequired(r.FileTypeRequired)
+}
+
+// ParseConfig checks whether the given value is valid for this rule's configuration and returns it as
+// UnsafeExecutionConfig. The value can be bool, or a map with 'trusted_url_prefixes' key, which enables the rule.
+func (r UnsafeExecution) ParseConfig(conf interface{}) (UnsafeExecutionConfig, error) {
+	var parsedConf UnsafeExecutionConfig
+
+	switch confValue := conf.(type) {
+	case bool:
+		parsedConf.Enabled = confValue
+	case map[interface{}]interface{}:
+		parsedConf.Enabled = true
+
+		for key, value := range confValue {
+			if key != "trusted_url_prefixes" {
+				return parsedConf, errValueNotBoolOrMap
+			}
+
+			values, ok := value.([]interface{})
+			if !ok {
+				return parsedConf, errValueNotStringArray
+			}
+
+			for _, v := range values {
+				str, ok := v.(string)
+				if !ok {
+					return parsedConf, errValueNotStringArray
+				}
+
+				parsedConf.TrustedURLPrefixes = append(parsedConf.TrustedURLPrefixes, str)
+			}
+		}
+	default:
+		return parsedConf, errValueNotBoolOrMap
+	}
+
+	return parsedConf, nil
+}
+
+// Check runs a rule with the specified configuration on a dotgithub.File (action or workflow), reports any errors
+// to the reporter, and returns whether the file is compliant.
+func (r UnsafeExecution) Check(
+	ctx context.Context,
+	conf UnsafeExecutionConfig,
+	file dotgithub.File,
+	_ *dotgithub.DotGithub,
+	reporter rule.Reporter,
+) (bool, error) {
+	if !conf.Enabled {
+		return true, nil
+	}
+
+	checker := &unsafeExecutionChecker{conf: conf, reporter: reporter, compliant: true}
+
+	switch fileInstance := file.(type) {
+	case *action.Action:
+		if fileInstance.Runs == nil {
+			return true, nil
+		}
+
+		checker.positions = rule.NewPositions(fileInstance.Raw)
+		checker.checkSteps(ctx, "", fileInstance.Runs.Steps)
+	case *workflow.Workflow:
+		checker.positions = rule.NewPositions(fileInstance.Raw)
+
+		for _, jobName := range fileInstance.GetJobNames() {
+			checker.checkSteps(ctx, fmt.Sprintf("job '%s' ", jobName), fileInstance.Jobs[jobName].Steps)
+		}
+	default:
+		return false, errFileInvalidType
+	}
+
+	return checker.compliant, ctx.Err() //nolint:wrapcheck
+}
+
+type unsafeExecutionChecker struct {
+	conf      UnsafeExecutionConfig
+	reporter  rule.Reporter
+	positions *rule.Positions
+	compliant bool
+}
+
+func (c *unsafeExecutionChecker) checkSteps(ctx context.Context, msgPrefix string, steps []*step.Step) {
+	for stepIdx, stepInstance := range steps {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if stepInstance.Run != "" {
+			c.checkScript(fmt.Sprintf("%sstep %d", msgPrefix, stepIdx+1), stepInstance.Run)
+		}
+	}
+}
+
+func (c *unsafeExecutionChecker) checkScript(stepName string, script string) {
+	lines := getScriptLines(script)
+	isVerified := regexpChecksum.MatchString(script)
+	downloadLines := []string{}
+
+	for _, line := range lines {
+		if regexpDownload.MatchString(line.text) && !isTrusted(line.text, c.conf.TrustedURLPrefixes) {
+			downloadLines = append(downloadLines, line.text)
+		}
+	}
+
+	for _, line := range lines {
+		// every line is looked up, so that repeated lines get their own positions
+		position := c.positions.Next(line.firstLine)
+
+		if regexpPipeToInterpreter.MatchString(line.text) || regexpInterpreterSubstitution.MatchString(line.text) {
+			if !isTrusted(line.text, c.conf.TrustedURLPrefixes) {
+				c.report(position, rule.SeverityDefault, rule.Diagnostic{
+					Message: stepName + " runs remote content with an interpreter without verifying it",
+					Fix: &glitch.Fix{
+						Message: "download the script to a file, verify its checksum, eg. with 'sha256sum -c', " +
+							"and run it afterwards",
+					},
+				})
+			}
+		}
+
+		if match := regexpChmodX.FindStringSubmatch(line.text); match != nil && !isVerified &&
+			isDownloadedFile(match[4], downloadLines) {
+			c.report(position, rule.SeverityDefault, rule.Diagnostic{
+				Message: fmt.Sprintf(
+					"%s makes downloaded '%s' executable without verifying its checksum",
+					stepName,
+					match[4],
+				),
+				Fix: &glitch.Fix{
+					Message: "verify the checksum of the file, eg. with 'sha256sum -c', before running it",
+				},
+			})
+		}
+
+		if regexpSudo.MatchString(line.text) {
+			c.report(position, rule.SeverityWarning, rule.Diagnostic{
+				Message: stepName + " uses 'sudo'",
+			})
+		}
+	}
+}
+
+func (c *unsafeExecutionChecker) report(
+	position glitch.Position,
+	severity rule.Severity,
+	diagnostic rule.Diagnostic,
+) {
+	diagnostic.Severity = severity
+	diagnostic.Position = position
+
+	c.reporter.Report(diagnostic)
+
+	if severity != rule.SeverityWarning {
+		c.compliant = false
+	}
+}
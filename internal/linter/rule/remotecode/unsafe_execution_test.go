@@ -0,0 +1,180 @@
+package remotecode
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/linter/ruletest"
+)
+
+func TestUnsafeExecutionParseConfig(t *testing.T) {
+	t.Parallel()
+
+	for _, confBad := range []interface{}{
+		4,
+		"true",
+		map[interface{}]interface{}{"trusted": []interface{}{"https://"}},
+		map[interface{}]interface{}{"trusted_url_prefixes": "https://"},
+	} {
+		_, err := UnsafeExecution{}.ParseConfig(confBad)
+		if err == nil {
+			t.Errorf("UnsafeExecution.ParseConfig should return error when conf is %v", confBad)
+		}
+	}
+
+	conf, err := UnsafeExecution{}.ParseConfig(map[interface{}]interface{}{
+		"trusted_url_prefixes": []interface{}{"https://example.com/"},
+	})
+	if err != nil || !conf.Enabled || len(conf.TrustedURLPrefixes) != 1 {
+		t.Errorf("UnsafeExecution.ParseConfig should enable the rule with trusted URL prefixes, got %+v and %v", conf, err)
+	}
+}
+
+func TestUnsafeExecution(t *testing.T) {
+	t.Parallel()
+
+	d := ruletest.GetDotGithub()
+
+	for _, tt := range []struct {
+		fileTypeRequired string
+		conf             interface{}
+		compliant        bool
+		expected         []string
+	}{
+		{
+			fileTypeRequired: "workflow",
+			conf:             true,
+			compliant:        false,
+			expected: []string{
+				"job 'main' step 1 runs remote content with an interpreter without verifying it",
+				"job 'main' step 1 uses 'sudo'",
+				"job 'main' step 2 runs remote content with an interpreter without verifying it",
+				"job 'main' step 2 runs remote content with an interpreter without verifying it",
+				"job 'main' step 3 makes downloaded '/usr/local/bin/tool' executable without verifying its checksum",
+				"job 'main' step 4 uses 'sudo'",
+			},
+		},
+		{
+			fileTypeRequired: "workflow",
+			conf: map[interface{}]interface{}{
+				"trusted_url_prefixes": []interface{}{
+					"https://raw.githubusercontent.com/my-org/",
+					"https://example.com/releases/",
+				},
+			},
+			compliant: false,
+			expected: []string{
+				"job 'main' step 1 runs remote content with an interpreter without verifying it",
+				"job 'main' step 1 uses 'sudo'",
+				"job 'main' step 2 runs remote content with an interpreter without verifying it",
+				"job 'main' step 4 uses 'sudo'",
+			},
+		},
+		{
+			fileTypeRequired: "action",
+			conf:             true,
+			compliant:        false,
+			expected: []string{
+				"step 1 runs remote content with an interpreter without verifying it",
+			},
+		},
+		{
+			fileTypeRequired: "action",
+			conf:             map[interface{}]interface{}{"trusted_url_prefixes": []interface{}{"https://get.example.com/"}},
+			compliant:        true,
+			expected:         []string{},
+		},
+	} {
+		checker, err := rule.NewChecker(UnsafeExecution{FileTypeRequired: tt.fileTypeRequired}, tt.conf)
+		if err != nil {
+			t.Fatalf("NewChecker failed with an error: %s", err.Error())
+		}
+
+		fn := func(f dotgithub.File, n string) {
+			compliant, ruleErrors, err := ruletest.Check(2, checker, f, d)
+			if compliant != tt.compliant {
+				t.Errorf("UnsafeExecution.Check on %s should return %v when conf is %v", n, tt.compliant, tt.conf)
+			}
+
+			if err != nil {
+				t.Errorf("UnsafeExecution.Check on %s failed with an error: %s", n, err.Error())
+			}
+
+			joinedErrors := strings.Join(ruleErrors, "\n")
+			if len(ruleErrors) != len(tt.expected) {
+				t.Errorf(
+					"UnsafeExecution.Check on %s should report %d errors when conf is %v, got [%s]",
+					n,
+					len(tt.expected),
+					tt.conf,
+					joinedErrors,
+				)
+			}
+
+			for _, e := range tt.expected {
+				if !strings.Contains(joinedErrors, e) {
+					t.Errorf("UnsafeExecution.Check on %s should report %s, got [%s]", n, e, joinedErrors)
+				}
+			}
+		}
+
+		if tt.fileTypeRequired == "action" {
+			ruletest.Action(d, "remotecode-unsafe-execution", fn)
+		} else {
+			ruletest.Workflow(d, "remotecode-unsafe-execution.yml", fn)
+		}
+	}
+}
+
+func TestUnsafeExecutionRepeatedLinePositions(t *testing.T) {
+	t.Parallel()
+
+	checker, _ := rule.NewChecker(UnsafeExecution{FileTypeRequired: "workflow"}, true)
+
+	d, err := dotgithub.NewFromFiles(context.Background(), map[string][]byte{
+		"workflows/main.yml": []byte(
+			"on: push\njobs:\n  main:\n    runs-on: ubuntu-24.04\n    steps:\n" +
+				"      - run: curl -fsSL https://get.example.com/install.sh | bash\n" +
+				"      - run: |\n          make\n          curl -fsSL https://get.example.com/install.sh | bash\n",
+		),
+	})
+	if err != nil {
+		t.Fatalf("NewFromFiles failed with an error: %s", err.Error())
+	}
+
+	diagnostics, err := ruletest.Diagnostics(2, checker, d.Workflows["main.yml"], d)
+	if err != nil || len(diagnostics) != 2 || diagnostics[0].Position.Line != 6 || diagnostics[1].Position.Line != 9 {
+		t.Errorf(
+			"UnsafeExecution.Check should report a repeated line on each of its lines, got %+v and %v",
+			diagnostics,
+			err,
+		)
+	}
+}
+
+func TestUnsafeExecutionJobOrderPositions(t *testing.T) {
+	t.Parallel()
+
+	checker, _ := rule.NewChecker(UnsafeExecution{FileTypeRequired: "workflow"}, true)
+	steps := "    steps:\n      - run: curl -fsSL https://get.example.com/install.sh | bash\n"
+
+	d, err := dotgithub.NewFromFiles(context.Background(), map[string][]byte{
+		"workflows/main.yml": []byte(
+			"on: push\njobs:\n  zeta:\n    runs-on: ubuntu-24.04\n" + steps +
+				"  alpha:\n    runs-on: ubuntu-24.04\n" + steps,
+		),
+	})
+	if err != nil {
+		t.Fatalf("NewFromFiles failed with an error: %s", err.Error())
+	}
+
+	diagnostics, err := ruletest.Diagnostics(2, checker, d.Workflows["main.yml"], d)
+	if err != nil || len(diagnostics) != 2 ||
+		!strings.HasPrefix(diagnostics[0].Message, "job 'zeta' ") || diagnostics[0].Position.Line != 6 ||
+		!strings.HasPrefix(diagnostics[1].Message, "job 'alpha' ") || diagnostics[1].Position.Line != 10 {
+		t.Errorf("UnsafeExecution.Check should report each job on its own line, got %+v and %v", diagnostics, err)
+	}
+}
@@ -0,0 +1,144 @@
+package runners
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/workflow"
+)
+
+const dateLayout = "2006-01-02"
+
+//go:embed runner_images.yml
+var runnerImagesData []byte
+
+// RunnerImage is a runner image that GitHub has retired, or scheduled for retirement.
+type RunnerImage struct {
+	Label       string `yaml:"label"`
+	Retirement  string `yaml:"retirement"`
+	Replacement string `yaml:"replacement"`
+	// RetirementDate is Retirement parsed.
+	RetirementDate time.Time `yaml:"-"`
+}
+
+// DeprecatedImages checks whether jobs do not run on runner images that GitHub has retired, or scheduled for
+// retirement, eg. 'ubuntu-20.04'. The images are listed in the embedded runner_images.yml file. Such labels are
+// reported as warnings. Labels that are expressions are left to LabelsAllowed.
+type DeprecatedImages struct{}
+
+// ConfigName returns the name of the rule as defined in the configuration file.
+func (r DeprecatedImages) ConfigName(int) string {
+	return "workflow_runners__deprecated_images"
+}
+
+// FileType returns an integer that specifies the file types (action and/or workflow) the rule targets.
+func (r DeprecatedImages) FileType() int {
+	return rule.DotGithubFileTypeWorkflow
+}
+
+// ParseConfig checks whether the given value is valid for this rule's configuration and returns the runner images
+// to report, or nil when the rule is disabled.
+func (r DeprecatedImages) ParseConfig(conf interface{}) ([]RunnerImage, error) {
+	confValue, ok := conf.(bool)
+	if !ok {
+		return nil, errValueNotBool
+	}
+
+	if !confValue {
+		return nil, nil
+	}
+
+	return parseRunnerImages(runnerImagesData)
+}
+
+func parseRunnerImages(data []byte) ([]RunnerImage, error) {
+	parsed := struct {
+		Images []RunnerImage `yaml:"images"`
+	}{}
+
+	err := yaml.Unmarshal(data, &parsed)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling runner images: %w", err)
+	}
+
+	for i, image := range parsed.Images {
+		parsed.Images[i].RetirementDate, err = time.Parse(dateLayout, image.Retirement)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing retirement date of runner image %s: %w", image.Label, err)
+		}
+	}
+
+	return parsed.Images, nil
+}
+
+// NotCacheable marks the rule as not cacheable, as whether an image is retired depends on the current date.
+func (r DeprecatedImages) NotCacheable() {}
+
+// Check runs a rule with the specified configuration on a workflow, reports any errors to the reporter, and returns
+// whether the file is compliant.
+func (r DeprecatedImages) Check(
+	_ context.Context,
+	conf []RunnerImage,
+	file dotgithub.File,
+	_ *dotgithub.DotGithub,
+	reporter rule.Reporter,
+) (bool, error) {
+	workflowInstance, ok := file.(*workflow.Workflow)
+	if !ok {
+		return false, errFileInvalidType
+	}
+
+	if len(conf) == 0 {
+		return true, nil
+	}
+
+	now := time.Now()
+
+	for _, jobName := range slices.Sorted(maps.Keys(workflowInstance.Jobs)) {
+		for _, label := range getRunsOnLabels(workflowInstance.Jobs[jobName]) {
+			message := r.getLabelMessage(conf, label, now)
+			if message == "" {
+				continue
+			}
+
+			reporter.Report(rule.Diagnostic{
+				Message:  fmt.Sprintf("job '%s' %s", jobName, message),
+				Severity: rule.SeverityWarning,
+			})
+		}
+	}
+
+	return true, nil
+}
+
+// getLabelMessage returns a message about the label when it is a deprecated image, or an empty string.
+func (r DeprecatedImages) getLabelMessage(images []RunnerImage, label string, now time.Time) string {
+	for _, image := range images {
+		if !strings.EqualFold(image.Label, label) {
+			continue
+		}
+
+		status := "was retired on"
+		if now.Before(image.RetirementDate) {
+			status = "is scheduled for retirement on"
+		}
+
+		return fmt.Sprintf(
+			"runs on image '%s' that %s %s, use '%s' instead",
+			label,
+			status,
+			image.Retirement,
+			image.Replacement,
+		)
+	}
+
+	return ""
+}
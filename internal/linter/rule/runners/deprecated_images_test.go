@@ -0,0 +1,74 @@
+package runners
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/linter/ruletest"
+)
+
+func TestDeprecatedImagesParseConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := DeprecatedImages{}.ParseConfig(4)
+	if err == nil {
+		t.Errorf("DeprecatedImages.ParseConfig should return error when conf is not bool")
+	}
+
+	images, err := DeprecatedImages{}.ParseConfig(true)
+	if err != nil || len(images) == 0 {
+		t.Errorf("DeprecatedImages.ParseConfig should return the embedded images, got %v and %v", images, err)
+	}
+
+	images, err = DeprecatedImages{}.ParseConfig(false)
+	if err != nil || images != nil {
+		t.Errorf("DeprecatedImages.ParseConfig should return no images when conf is false")
+	}
+
+	_, err = parseRunnerImages([]byte("images:\n  - label: ubuntu-20.04\n    retirement: soon\n"))
+	if err == nil {
+		t.Errorf("parseRunnerImages should return error when retirement is not a date")
+	}
+}
+
+func TestDeprecatedImages(t *testing.T) {
+	t.Parallel()
+
+	checker, _ := rule.NewChecker(DeprecatedImages{}, true)
+	d := ruletest.GetDotGithub()
+
+	fn := func(f dotgithub.File, _ string) {
+		compliant, ruleErrors, err := ruletest.Check(2, checker, f, d)
+		if !compliant {
+			t.Errorf("DeprecatedImages.Check should return true as it reports warnings only")
+		}
+
+		if err != nil {
+			t.Errorf("DeprecatedImages.Check failed with an error: %s", err.Error())
+		}
+
+		joinedErrors := strings.Join(ruleErrors, "\n")
+		if len(ruleErrors) != 2 ||
+			!strings.Contains(joinedErrors, "job 'deprecated' runs on image 'ubuntu-20.04' that was retired on 2025-04-15") ||
+			!strings.Contains(joinedErrors, "job 'deprecated' runs on image 'macos-12'") {
+			t.Errorf("DeprecatedImages.Check should report retired images only, got [%s]", joinedErrors)
+		}
+	}
+
+	ruletest.Workflow(d, "runners-labels.yml", fn)
+}
+
+func TestDeprecatedImagesScheduled(t *testing.T) {
+	t.Parallel()
+
+	images, _ := parseRunnerImages([]byte("images:\n  - label: ubuntu-22.04\n    retirement: 2027-01-01\n" +
+		"    replacement: ubuntu-24.04\n"))
+
+	message := DeprecatedImages{}.getLabelMessage(images, "ubuntu-22.04", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	if message != "runs on image 'ubuntu-22.04' that is scheduled for retirement on 2027-01-01, use 'ubuntu-24.04' instead" {
+		t.Errorf("getLabelMessage should report an image that is scheduled for retirement, got '%s'", message)
+	}
+}
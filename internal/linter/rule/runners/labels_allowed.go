@@ -0,0 +1,115 @@
+package runners
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/workflow"
+)
+
+// LabelsAllowed checks whether jobs run only on runners with approved labels. A pattern can contain wildcards, eg.
+// 'ubuntu-*-16core'. Labels that are expressions, and cannot be resolved from the matrix, are reported as warnings
+// as they cannot be checked statically. An empty list of patterns turns the rule off.
+type LabelsAllowed struct{}
+
+// ConfigName returns the name of the rule as defined in the configuration file.
+func (r LabelsAllowed) ConfigName(int) string {
+	return "workflow_runners__labels_allowed"
+}
+
+// FileType returns an integer that specifies the file types (action and/or workflow) the rule targets.
+func (r LabelsAllowed) FileType() int {
+	return rule.DotGithubFileTypeWorkflow
+}
+
+// ParseConfig checks whether the given value is valid for this rule's configuration and returns the patterns.
+func (r LabelsAllowed) ParseConfig(conf interface{}) ([]string, error) {
+	vals, ok := conf.([]interface{})
+	if !ok {
+		return nil, errValueNotStringArray
+	}
+
+	patterns := make([]string, 0, len(vals))
+
+	for _, v := range vals {
+		pattern, ok := v.(string)
+		if !ok {
+			return nil, errValueNotStringArray
+		}
+
+		_, err := path.Match(pattern, "")
+		if err != nil {
+			return nil, fmt.Errorf("error parsing runner label pattern %s: %w", pattern, err)
+		}
+
+		patterns = append(patterns, pattern)
+	}
+
+	return patterns, nil
+}
+
+// Check runs a rule with the specified configuration on a workflow, reports any errors to the reporter, and returns
+// whether the file is compliant.
+func (r LabelsAllowed) Check(
+	_ context.Context,
+	conf []string,
+	file dotgithub.File,
+	_ *dotgithub.DotGithub,
+	reporter rule.Reporter,
+) (bool, error) {
+	workflowInstance, ok := file.(*workflow.Workflow)
+	if !ok {
+		return false, errFileInvalidType
+	}
+
+	if len(conf) == 0 {
+		return true, nil
+	}
+
+	compliant := true
+
+	for _, jobName := range slices.Sorted(maps.Keys(workflowInstance.Jobs)) {
+		for _, label := range getRunsOnLabels(workflowInstance.Jobs[jobName]) {
+			if isExpression(label) {
+				reporter.Report(rule.Diagnostic{
+					Message: fmt.Sprintf(
+						"job '%s' has 'runs-on' label '%s' that is an expression and cannot be checked statically",
+						jobName,
+						label,
+					),
+					Severity: rule.SeverityWarning,
+				})
+
+				continue
+			}
+
+			if r.isAllowed(conf, label) {
+				continue
+			}
+
+			compliant = false
+
+			reporter.Report(rule.Diagnostic{
+				Message: fmt.Sprintf("job '%s' has 'runs-on' label '%s' that is not allowed", jobName, label),
+			})
+		}
+	}
+
+	return compliant, nil
+}
+
+func (r LabelsAllowed) isAllowed(patterns []string, label string) bool {
+	for _, pattern := range patterns {
+		match, _ := path.Match(pattern, label)
+		if match {
+			return true
+		}
+	}
+
+	return false
+}
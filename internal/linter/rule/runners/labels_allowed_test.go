@@ -0,0 +1,75 @@
+package runners
+
+import (
+	"strings"
+	"testing"
+
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/linter/ruletest"
+)
+
+func TestLabelsAllowedParseConfig(t *testing.T) {
+	t.Parallel()
+
+	for _, confBad := range []interface{}{true, "ubuntu-*", []interface{}{4}, []interface{}{"ubuntu-["}} {
+		_, err := LabelsAllowed{}.ParseConfig(confBad)
+		if err == nil {
+			t.Errorf("LabelsAllowed.ParseConfig should return error when conf is %v", confBad)
+		}
+	}
+
+	_, err := LabelsAllowed{}.ParseConfig([]interface{}{"ubuntu-*", "self-hosted"})
+	if err != nil {
+		t.Errorf("LabelsAllowed.ParseConfig should not return error when conf contains patterns")
+	}
+}
+
+func TestLabelsAllowed(t *testing.T) {
+	t.Parallel()
+
+	checker, _ := rule.NewChecker(LabelsAllowed{}, []interface{}{"ubuntu-*", "macos-*", "self-hosted"})
+	d := ruletest.GetDotGithub()
+
+	fn := func(f dotgithub.File, _ string) {
+		compliant, ruleErrors, err := ruletest.Check(2, checker, f, d)
+		if compliant {
+			t.Errorf("LabelsAllowed.Check should return false when a label is not allowed")
+		}
+
+		if err != nil {
+			t.Errorf("LabelsAllowed.Check failed with an error: %s", err.Error())
+		}
+
+		joinedErrors := strings.Join(ruleErrors, "\n")
+		if len(ruleErrors) != 2 ||
+			!strings.Contains(joinedErrors, "job 'not-allowed' has 'runs-on' label 'gpu' that is not allowed") ||
+			!strings.Contains(joinedErrors, "label '${{ inputs.runner }}' that is an expression") {
+			t.Errorf(
+				"LabelsAllowed.Check should report a label that is not allowed and an expression, got [%s]",
+				joinedErrors,
+			)
+		}
+	}
+
+	ruletest.Workflow(d, "runners-labels.yml", fn)
+}
+
+func TestLabelsAllowedEmpty(t *testing.T) {
+	t.Parallel()
+
+	checker, _ := rule.NewChecker(LabelsAllowed{}, []interface{}{})
+	d := ruletest.GetDotGithub()
+
+	fn := func(f dotgithub.File, _ string) {
+		compliant, ruleErrors, err := ruletest.Check(2, checker, f, d)
+		if !compliant || len(ruleErrors) != 0 || err != nil {
+			t.Errorf(
+				"LabelsAllowed.Check should not report anything when conf is empty, got [%s]",
+				strings.Join(ruleErrors, "\n"),
+			)
+		}
+	}
+
+	ruletest.Workflow(d, "runners-labels.yml", fn)
+}
@@ -1,9 +1,86 @@
 // Package runners contains rules checking GitHub Actions' runners.
 package runners
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"octo-linter/internal/workflow"
+)
 
 var (
-	errValueNotBool    = errors.New("value should be bool")
-	errFileInvalidType = errors.New("file is of invalid type")
+	errValueNotBool        = errors.New("value should be bool")
+	errValueNotStringArray = errors.New("value should be []string")
+	errFileInvalidType     = errors.New("file is of invalid type")
 )
+
+var regexpMatrixRef = regexp.MustCompile(`^\${{\s*matrix\.([a-zA-Z0-9_\-]+)\s*}}$`)
+
+// getRunsOnLabels returns labels from 'runs-on' of the job, in any of its forms. A label that is a matrix value, eg.
+// '${{ matrix.os }}', is replaced with all the values of it, from the matrix and 'include'.
+func getRunsOnLabels(job *workflow.Job) []string {
+	runsOn := job.GetRunsOn()
+	if runsOn == nil {
+		return nil
+	}
+
+	labels := []string{}
+	for _, label := range runsOn.Labels {
+		labels = append(labels, resolveMatrixRef(job, label)...)
+	}
+
+	return labels
+}
+
+// isExpression checks whether the label contains an expression that could not be resolved, hence the label cannot
+// be checked statically.
+func isExpression(label string) bool {
+	return strings.Contains(label, "${{")
+}
+
+func resolveMatrixRef(job *workflow.Job, label string) []string {
+	matches := regexpMatrixRef.FindStringSubmatch(strings.TrimSpace(label))
+	if matches == nil || job.Strategy == nil {
+		return []string{label}
+	}
+
+	matrix := job.Strategy.GetMatrix()
+	if matrix == nil || matrix.Expression != "" {
+		return []string{label}
+	}
+
+	values := []string{}
+	for _, value := range matrix.Dimensions[matches[1]] {
+		values = append(values, valueToLabels(value)...)
+	}
+
+	for _, include := range matrix.Include {
+		value, ok := include[matches[1]]
+		if ok {
+			values = append(values, valueToLabels(value)...)
+		}
+	}
+
+	if len(values) == 0 {
+		return []string{label}
+	}
+
+	return values
+}
+
+// valueToLabels converts a matrix value to labels. The value can be a list of labels too.
+func valueToLabels(value interface{}) []string {
+	list, isList := value.([]interface{})
+	if !isList {
+		return []string{fmt.Sprint(value)}
+	}
+
+	labels := make([]string, 0, len(list))
+	for _, item := range list {
+		labels = append(labels, fmt.Sprint(item))
+	}
+
+	return labels
+}
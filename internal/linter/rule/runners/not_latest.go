@@ -1,17 +1,20 @@
 package runners
 
 import (
+	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"octo-linter/internal/dotgithub"
-	"octo-linter/internal/linter/glitch"
 	"octo-linter/internal/linter/rule"
 	"octo-linter/internal/workflow"
 )
 
 // NotLatest checks whether 'runs-on' does not contain the 'latest' string. In some case, runner version (image)
-// should be frozen, instead of using the latest.
+// should be frozen, instead of using the latest. Labels in a list, in a 'group'/'labels' mapping, and values of a
+// matrix used in 'runs-on' are checked.
 type NotLatest struct{}
 
 // ConfigName returns the name of the rule as defined in the configuration file.
@@ -24,98 +27,55 @@ func (r NotLatest) FileType() int {
 	return rule.DotGithubFileTypeWorkflow
 }
 
-// Validate checks whether the given value is valid for this rule's configuration.
-func (r NotLatest) Validate(conf interface{}) error {
-	_, ok := conf.(bool)
+// ParseConfig checks whether the given value is valid for this rule's configuration and returns it as bool.
+func (r NotLatest) ParseConfig(conf interface{}) (bool, error) {
+	confValue, ok := conf.(bool)
 	if !ok {
-		return errValueNotBool
+		return false, errValueNotBool
 	}
 
-	return nil
+	return confValue, nil
 }
 
-// Lint runs a rule with the specified configuration on a dotgithub.File (action or workflow),
-// reports any errors via the given channel, and returns whether the file is compliant.
-func (r NotLatest) Lint(
-	conf interface{},
+// Check runs a rule with the specified configuration on a workflow, reports any errors to the reporter, and returns
+// whether the file is compliant.
+func (r NotLatest) Check(
+	_ context.Context,
+	conf bool,
 	file dotgithub.File,
 	_ *dotgithub.DotGithub,
-	chErrors chan<- glitch.Glitch,
+	reporter rule.Reporter,
 ) (bool, error) {
-	confValue, confIsBool := conf.(bool)
-	if !confIsBool {
-		return false, errValueNotBool
-	}
-
-	if file.GetType() != rule.DotGithubFileTypeWorkflow {
-		return true, nil
-	}
-
 	workflowInstance, ok := file.(*workflow.Workflow)
 	if !ok {
 		return false, errFileInvalidType
 	}
 
-	if !confValue || len(workflowInstance.Jobs) == 0 {
+	if !conf || len(workflowInstance.Jobs) == 0 {
 		return true, nil
 	}
 
 	compliant := true
 
-	for jobName, job := range workflowInstance.Jobs {
-		if job.RunsOn == nil {
-			continue
-		}
-
-		foundNotCompliant := r.processRunsOn(job.RunsOn, jobName, workflowInstance, chErrors)
-		if foundNotCompliant {
+	for _, jobName := range slices.Sorted(maps.Keys(workflowInstance.Jobs)) {
+		if !r.isRunsOnCompliant(workflowInstance.Jobs[jobName]) {
 			compliant = false
+
+			reporter.Report(rule.Diagnostic{
+				Message: fmt.Sprintf("job '%s' should not use 'latest' in 'runs-on' field", jobName),
+			})
 		}
 	}
 
 	return compliant, nil
 }
 
-func (r NotLatest) processRunsOn(
-	jobRunsOn interface{},
-	jobName string,
-	workflowInstance *workflow.Workflow,
-	chErrors chan<- glitch.Glitch,
-) bool {
-	foundNotCompliant := false
-
-	runsOnStr, runsOnIsString := jobRunsOn.(string)
-	if runsOnIsString {
-		if strings.Contains(runsOnStr, "latest") {
-			foundNotCompliant = true
-
-			chErrors <- glitch.Glitch{
-				Path:     workflowInstance.Path,
-				Name:     workflowInstance.DisplayName,
-				Type:     rule.DotGithubFileTypeWorkflow,
-				ErrText:  fmt.Sprintf("job '%s' should not use 'latest' in 'runs-on' field", jobName),
-				RuleName: r.ConfigName(0),
-			}
-		}
-	}
-
-	runsOnList, runsOnIsList := jobRunsOn.([]interface{})
-	if runsOnIsList {
-		for _, runsOn := range runsOnList {
-			runsOnStr, ok2 := runsOn.(string)
-			if ok2 && strings.Contains(runsOnStr, "latest") {
-				foundNotCompliant = true
-
-				chErrors <- glitch.Glitch{
-					Path:     workflowInstance.Path,
-					Name:     workflowInstance.DisplayName,
-					Type:     rule.DotGithubFileTypeWorkflow,
-					ErrText:  fmt.Sprintf("job '%s' should not use 'latest' in 'runs-on' field", jobName),
-					RuleName: r.ConfigName(0),
-				}
-			}
+func (r NotLatest) isRunsOnCompliant(job *workflow.Job) bool {
+	for _, label := range getRunsOnLabels(job) {
+		if strings.Contains(label, "latest") {
+			return false
 		}
 	}
 
-	return foundNotCompliant
+	return true
 }
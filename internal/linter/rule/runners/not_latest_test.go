@@ -5,50 +5,53 @@ import (
 	"testing"
 
 	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/rule"
 	"octo-linter/internal/linter/ruletest"
 )
 
-func TestNotLatestValidate(t *testing.T) {
+func TestNotLatestParseConfig(t *testing.T) {
 	t.Parallel()
 
 	rule := NotLatest{}
 
 	confBad := 4
 
-	err := rule.Validate(confBad)
+	_, err := rule.ParseConfig(confBad)
 	if err == nil {
-		t.Errorf("NotLatest.Validate should return error when conf is not bool")
+		t.Errorf("NotLatest.ParseConfig should return error when conf is not bool")
 	}
 
 	confGood := true
 
-	err = rule.Validate(confGood)
-	if err != nil {
-		t.Errorf("NotLatest.Validate should not return error when conf is bool")
+	conf, err := rule.ParseConfig(confGood)
+	if err != nil || !conf {
+		t.Errorf("NotLatest.ParseConfig should return the value when conf is bool")
 	}
 }
 
 func TestNotLatestNotCompliant(t *testing.T) {
 	t.Parallel()
 
-	rule := NotLatest{}
-	conf := true
+	checker, _ := rule.NewChecker(NotLatest{}, true)
 	d := ruletest.GetDotGithub()
 
 	fn := func(f dotgithub.File, _ string) {
-		compliant, ruleErrors, err := ruletest.Lint(2, rule, conf, f, d)
+		compliant, ruleErrors, err := ruletest.Check(2, checker, f, d)
 		if compliant {
 			t.Errorf(
-				"NotLatest.Lint should return false when 'latest' is found in at least one job",
+				"NotLatest.Check should return false when 'latest' is found in at least one job",
 			)
 		}
 
 		if err != nil {
-			t.Errorf("NotLatest.Lint failed with an error: %s", err.Error())
+			t.Errorf("NotLatest.Check failed with an error: %s", err.Error())
 		}
 
-		if len(ruleErrors) == 0 {
-			t.Errorf("NotLatest.Lint should send an error over the channel")
+		if len(ruleErrors) != 2 {
+			t.Errorf(
+				"NotLatest.Check should report 2 errors, including one for 'labels', got [%s]",
+				strings.Join(ruleErrors, "\n"),
+			)
 		}
 	}
 
@@ -58,23 +61,22 @@ func TestNotLatestNotCompliant(t *testing.T) {
 func TestNotLatestCompliant(t *testing.T) {
 	t.Parallel()
 
-	rule := NotLatest{}
-	conf := true
+	checker, _ := rule.NewChecker(NotLatest{}, true)
 	d := ruletest.GetDotGithub()
 
 	fn := func(f dotgithub.File, _ string) {
-		compliant, ruleErrors, err := ruletest.Lint(2, rule, conf, f, d)
+		compliant, ruleErrors, err := ruletest.Check(2, checker, f, d)
 		if !compliant {
-			t.Errorf("NotLatest.Lint should return true when 'latest' is not in any job")
+			t.Errorf("NotLatest.Check should return true when 'latest' is not in any job")
 		}
 
 		if err != nil {
-			t.Errorf("NotLatest.Lint failed with an error: %s", err.Error())
+			t.Errorf("NotLatest.Check failed with an error: %s", err.Error())
 		}
 
 		if len(ruleErrors) > 0 {
 			t.Errorf(
-				"NotLatest.Lint should not send any error over the channel, sent %s",
+				"NotLatest.Check should not report any error, reported %s",
 				strings.Join(ruleErrors, "|"),
 			)
 		}
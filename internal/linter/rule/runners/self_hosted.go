@@ -0,0 +1,137 @@
+package runners
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/workflow"
+)
+
+const labelSelfHosted = "self-hosted"
+
+// SelfHostedNotOnForkPullRequests checks whether jobs of workflows that can be triggered from a fork, ie. by
+// 'pull_request', 'pull_request_target' or 'issue_comment', do not run on self-hosted runners. Code from a fork could
+// then run on, and compromise, the runner. A runner is self-hosted when one of its labels, or its group, is
+// 'self-hosted' or matches one of the configured patterns.
+type SelfHostedNotOnForkPullRequests struct{}
+
+// ConfigName returns the name of the rule as defined in the configuration file.
+func (r SelfHostedNotOnForkPullRequests) ConfigName(int) string {
+	return "workflow_runners__self_hosted_not_on_fork_pull_requests"
+}
+
+// FileType returns an integer that specifies the file types (action and/or workflow) the rule targets.
+func (r SelfHostedNotOnForkPullRequests) FileType() int {
+	return rule.DotGithubFileTypeWorkflow
+}
+
+// ParseConfig checks whether the given value is valid for this rule's configuration and returns the compiled
+// patterns of self-hosted runner labels.
+func (r SelfHostedNotOnForkPullRequests) ParseConfig(conf interface{}) ([]*regexp.Regexp, error) {
+	vals, ok := conf.([]interface{})
+	if !ok {
+		return nil, errValueNotStringArray
+	}
+
+	patterns := make([]*regexp.Regexp, 0, len(vals))
+
+	for _, v := range vals {
+		pattern, ok := v.(string)
+		if !ok {
+			return nil, errValueNotStringArray
+		}
+
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("error compiling self-hosted label pattern %s: %w", pattern, err)
+		}
+
+		patterns = append(patterns, compiled)
+	}
+
+	return patterns, nil
+}
+
+// Check runs a rule with the specified configuration on a workflow, reports any errors to the reporter, and returns
+// whether the file is compliant.
+func (r SelfHostedNotOnForkPullRequests) Check(
+	_ context.Context,
+	conf []*regexp.Regexp,
+	file dotgithub.File,
+	_ *dotgithub.DotGithub,
+	reporter rule.Reporter,
+) (bool, error) {
+	workflowInstance, ok := file.(*workflow.Workflow)
+	if !ok {
+		return false, errFileInvalidType
+	}
+
+	forkEvents := []string{}
+
+	for _, event := range []string{
+		workflow.EventPullRequest,
+		workflow.EventPullRequestTarget,
+		workflow.EventIssueComment,
+	} {
+		if workflowInstance.On.HasEvent(event) {
+			forkEvents = append(forkEvents, event)
+		}
+	}
+
+	if len(forkEvents) == 0 {
+		return true, nil
+	}
+
+	compliant := true
+
+	for _, jobName := range slices.Sorted(maps.Keys(workflowInstance.Jobs)) {
+		label := r.getSelfHostedLabel(conf, workflowInstance.Jobs[jobName])
+		if label == "" {
+			continue
+		}
+
+		compliant = false
+
+		reporter.Report(rule.Diagnostic{
+			Message: fmt.Sprintf(
+				"job '%s' runs on self-hosted runner '%s' in a workflow triggered by '%s', which can run code from forks",
+				jobName,
+				label,
+				strings.Join(forkEvents, "', '"),
+			),
+		})
+	}
+
+	return compliant, nil
+}
+
+// getSelfHostedLabel returns the runner group, or the first label of the job, that is of a self-hosted runner, or an
+// empty string.
+func (r SelfHostedNotOnForkPullRequests) getSelfHostedLabel(patterns []*regexp.Regexp, job *workflow.Job) string {
+	labels := getRunsOnLabels(job)
+
+	runsOn := job.GetRunsOn()
+	if runsOn != nil && runsOn.Group != "" {
+		labels = append([]string{runsOn.Group}, labels...)
+	}
+
+	for _, label := range labels {
+		if strings.EqualFold(label, labelSelfHosted) {
+			return label
+		}
+
+		for _, pattern := range patterns {
+			if pattern.MatchString(label) {
+				return label
+			}
+		}
+	}
+
+	return ""
+}
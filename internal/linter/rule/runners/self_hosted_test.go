@@ -0,0 +1,95 @@
+package runners
+
+import (
+	"strings"
+	"testing"
+
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/linter/ruletest"
+)
+
+func TestSelfHostedNotOnForkPullRequestsParseConfig(t *testing.T) {
+	t.Parallel()
+
+	for _, confBad := range []interface{}{true, "self-hosted", []interface{}{4}, []interface{}{"("}} {
+		_, err := SelfHostedNotOnForkPullRequests{}.ParseConfig(confBad)
+		if err == nil {
+			t.Errorf("SelfHostedNotOnForkPullRequests.ParseConfig should return error when conf is %v", confBad)
+		}
+	}
+
+	patterns, err := SelfHostedNotOnForkPullRequests{}.ParseConfig([]interface{}{"^prod-"})
+	if err != nil || len(patterns) != 1 {
+		t.Errorf("SelfHostedNotOnForkPullRequests.ParseConfig should return compiled patterns")
+	}
+}
+
+func TestSelfHostedNotOnForkPullRequests(t *testing.T) {
+	t.Parallel()
+
+	d := ruletest.GetDotGithub()
+
+	for _, tt := range []struct {
+		conf      []interface{}
+		expected  []string
+		workflows []string
+	}{
+		{
+			conf:      []interface{}{},
+			expected:  []string{"job 'list' runs on self-hosted runner 'self-hosted'"},
+			workflows: []string{"runners-self-hosted.yml"},
+		},
+		{
+			conf: []interface{}{"^prod-", "^build-"},
+			expected: []string{
+				"job 'group' runs on self-hosted runner 'prod-runners'",
+				"job 'list' runs on self-hosted runner 'self-hosted'",
+				"job 'matrix' runs on self-hosted runner 'build-x64' in a workflow triggered by " +
+					"'pull_request', 'issue_comment'",
+			},
+			workflows: []string{"runners-self-hosted.yml"},
+		},
+		{
+			conf:      []interface{}{"^ubuntu-"},
+			expected:  []string{},
+			workflows: []string{"valid-workflow.yml"},
+		},
+	} {
+		checker, err := rule.NewChecker(SelfHostedNotOnForkPullRequests{}, tt.conf)
+		if err != nil {
+			t.Fatalf("NewChecker failed with an error: %s", err.Error())
+		}
+
+		fn := func(f dotgithub.File, n string) {
+			compliant, ruleErrors, err := ruletest.Check(2, checker, f, d)
+			if compliant != (len(tt.expected) == 0) {
+				t.Errorf("SelfHostedNotOnForkPullRequests.Check on %s should return %v", n, len(tt.expected) == 0)
+			}
+
+			if err != nil {
+				t.Errorf("SelfHostedNotOnForkPullRequests.Check on %s failed with an error: %s", n, err.Error())
+			}
+
+			joinedErrors := strings.Join(ruleErrors, "\n")
+			if len(ruleErrors) != len(tt.expected) {
+				t.Errorf(
+					"SelfHostedNotOnForkPullRequests.Check on %s should report %d errors, got [%s]",
+					n,
+					len(tt.expected),
+					joinedErrors,
+				)
+			}
+
+			for _, e := range tt.expected {
+				if !strings.Contains(joinedErrors, e) {
+					t.Errorf("SelfHostedNotOnForkPullRequests.Check on %s should report %s, got [%s]", n, e, joinedErrors)
+				}
+			}
+		}
+
+		for _, workflowName := range tt.workflows {
+			ruletest.Workflow(d, workflowName, fn)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Package triggers contains rules checking workflows run by privileged triggers, such as 'pull_request_target'.
+package triggers
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"octo-linter/internal/step"
+)
+
+var (
+	errValueNotBool    = errors.New("value should be bool")
+	errFileInvalidType = errors.New("file is of invalid type")
+)
+
+var (
+	// regexpHeadRef matches references to the head of a pull request or of the run that triggered 'workflow_run'.
+	regexpHeadRef = regexp.MustCompile(
+		`(?i)github\.event\.pull_request\.head\.(sha|ref|repo\.full_name)|github\.head_ref|` +
+			`github\.event\.workflow_run\.(head_sha|head_branch|head_repository\.full_name)|refs/pull/`,
+	)
+	regexpRunCheckout = regexp.MustCompile(
+		`(?i)gh\s+pr\s+checkout|git\s+(fetch|checkout|pull|switch)\s[^\n]*(pull/|head_ref|head\.(sha|ref)|head_sha|head_branch)`,
+	)
+	regexpSecret = regexp.MustCompile(`\${{[^}]*\bsecrets\.`)
+)
+
+// getActionPath returns the action called by the step without its ref, eg. 'actions/checkout'.
+func getActionPath(stepInstance *step.Step) string {
+	actionPath, _, _ := strings.Cut(stepInstance.Uses, "@")
+
+	return strings.ToLower(actionPath)
+}
+
+// isHeadCheckout checks whether the step checks out the code of the pull request, or of the triggering run.
+func isHeadCheckout(stepInstance *step.Step) bool {
+	if getActionPath(stepInstance) == "actions/checkout" {
+		return regexpHeadRef.MatchString(stepInstance.GetWith("ref")) ||
+			regexpHeadRef.MatchString(stepInstance.GetWith("repository"))
+	}
+
+	return regexpRunCheckout.MatchString(stepInstance.Run)
+}
+
+// isRunningCode checks whether the step runs a script or a local action, which come from the checked out code.
+func isRunningCode(stepInstance *step.Step) bool {
+	return stepInstance.IsRun() || strings.HasPrefix(stepInstance.Uses, "./")
+}
+
+// isUsingSecrets checks whether any of the values passed to the step, in 'env' or 'with', refers to secrets.
+func isUsingSecrets(values ...map[string]interface{}) bool {
+	for _, fields := range values {
+		for _, value := range fields {
+			str, ok := value.(string)
+			if ok && regexpSecret.MatchString(str) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
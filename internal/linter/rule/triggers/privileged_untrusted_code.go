@@ -0,0 +1,209 @@
+package triggers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/glitch"
+	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/step"
+	"octo-linter/internal/workflow"
+)
+
+// PrivilegedUntrustedCode checks workflows triggered by 'pull_request_target' or 'workflow_run'. They run with
+// secrets and a write token even for pull requests from forks, so they must not check out the code of the pull
+// request, run it, pass secrets to it, or use artifacts of the triggering run without validating them.
+type PrivilegedUntrustedCode struct{}
+
+// ConfigName returns the name of the rule as defined in the configuration file.
+func (r PrivilegedUntrustedCode) ConfigName(int) string {
+	return "privileged_triggers__workflow_untrusted_code_not_allowed"
+}
+
+// FileType returns an integer that specifies the file types (action and/or workflow) the rule targets.
+func (r PrivilegedUntrustedCode) FileType() int {
+	return rule.DotGithubFileTypeWorkflow
+}
+
+// ParseConfig checks whether the given value is valid for this rule's configuration and returns it as bool.
+func (r PrivilegedUntrustedCode) ParseConfig(conf interface{}) (bool, error) {
+	confValue, ok := conf.(bool)
+	if !ok {
+		return false, errValueNotBool
+	}
+
+	return confValue, nil
+}
+
+// Check runs a rule with the specified configuration on a workflow, reports any errors to the reporter, and returns
+// whether the file is compliant.
+func (r PrivilegedUntrustedCode) Check(
+	_ context.Context,
+	conf bool,
+	file dotgithub.File,
+	_ *dotgithub.DotGithub,
+	reporter rule.Reporter,
+) (bool, error) {
+	workflowInstance, ok := file.(*workflow.Workflow)
+	if !ok {
+		return false, errFileInvalidType
+	}
+
+	if !conf || !workflowInstance.On.HasAnyEvent(workflow.EventPullRequestTarget, workflow.EventWorkflowRun) {
+		return true, nil
+	}
+
+	isWorkflowRun := workflowInstance.On.HasEvent(workflow.EventWorkflowRun)
+	positions := rule.NewPositions(workflowInstance.Raw)
+	compliant := true
+
+	// jobs are walked in the order they appear in the file, so that steps get their own positions
+	for _, jobName := range workflowInstance.GetJobNames() {
+		job := workflowInstance.Jobs[jobName]
+		stepPositions := getStepPositions(positions, job.Steps)
+
+		for _, finding := range r.checkSteps(job, isWorkflowRun) {
+			reporter.Report(rule.Diagnostic{
+				Message:  fmt.Sprintf("job '%s' %s", jobName, finding.message),
+				Position: stepPositions[finding.stepIdx],
+				Fix:      &glitch.Fix{Message: finding.fix},
+			})
+
+			compliant = false
+		}
+	}
+
+	return compliant, nil
+}
+
+// untrustedCodeFinding is an issue found in a step of a job.
+type untrustedCodeFinding struct {
+	stepIdx int
+	message string
+	fix     string
+}
+
+func (r PrivilegedUntrustedCode) checkSteps(job *workflow.Job, isWorkflowRun bool) []untrustedCodeFinding {
+	findings := []untrustedCodeFinding{}
+	checkoutStepIdx := -1
+	runStepIdx := -1
+
+	for stepIdx, stepInstance := range job.Steps {
+		if isWorkflowRun && r.isDownloadingArtifactsToWorkspace(stepInstance) {
+			findings = append(findings, untrustedCodeFinding{
+				stepIdx: stepIdx,
+				message: fmt.Sprintf(
+					"step %d downloads artifacts of the triggering run into the workspace without validating them",
+					stepIdx+1,
+				),
+				fix: "download them to '${{ runner.temp }}' and treat them as untrusted data",
+			})
+		}
+
+		if checkoutStepIdx == -1 {
+			if isHeadCheckout(stepInstance) {
+				checkoutStepIdx = stepIdx
+
+				findings = append(findings, untrustedCodeFinding{
+					stepIdx: stepIdx,
+					message: fmt.Sprintf("step %d checks out the pull request head in a privileged workflow", stepIdx+1),
+					fix: "check out the base ref, or move the job to a workflow triggered by 'pull_request' " +
+						"that has no secrets",
+				})
+			}
+
+			continue
+		}
+
+		if isRunningCode(stepInstance) {
+			if runStepIdx == -1 {
+				runStepIdx = stepIdx
+			}
+
+			findings = append(findings, untrustedCodeFinding{
+				stepIdx: stepIdx,
+				message: fmt.Sprintf(
+					"step %d runs code after the pull request head is checked out in step %d",
+					stepIdx+1,
+					checkoutStepIdx+1,
+				),
+				fix: "check out the base ref, or run the pull request code in a workflow triggered by 'pull_request'",
+			})
+		}
+
+		if isUsingSecrets(stepInstance.Env, stepInstance.With) {
+			findings = append(findings, untrustedCodeFinding{
+				stepIdx: stepIdx,
+				message: fmt.Sprintf(
+					"step %d passes secrets to a step that runs after the pull request head is checked out in step %d",
+					stepIdx+1,
+					checkoutStepIdx+1,
+				),
+				fix: "move steps that need secrets to a separate job that does not check out the pull request head",
+			})
+		}
+	}
+
+	if runStepIdx != -1 && r.isJobEnvUsingSecrets(job) {
+		findings = append(findings, untrustedCodeFinding{
+			stepIdx: runStepIdx,
+			message: "has secrets in job 'env' that are available to the checked out code",
+			fix:     "move secrets to the 'env' of steps that need them, or to a separate job",
+		})
+	}
+
+	return findings
+}
+
+func (r PrivilegedUntrustedCode) isJobEnvUsingSecrets(job *workflow.Job) bool {
+	for _, value := range job.Env {
+		if regexpSecret.MatchString(value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isDownloadingArtifactsToWorkspace checks whether the step downloads artifacts of another run, ie. the triggering
+// one, anywhere else than to the runner's temporary directory. Artifacts in the workspace can overwrite scripts that
+// run later.
+func (r PrivilegedUntrustedCode) isDownloadingArtifactsToWorkspace(stepInstance *step.Step) bool {
+	switch getActionPath(stepInstance) {
+	case "actions/download-artifact":
+		if stepInstance.GetWith("run-id") == "" {
+			return false
+		}
+
+		return !isRunnerTemp(stepInstance.GetWith("path"))
+	case "dawidd6/action-download-artifact":
+		return !isRunnerTemp(stepInstance.GetWith("path"))
+	}
+
+	return strings.Contains(stepInstance.Run, "gh run download") && !isRunnerTemp(stepInstance.Run)
+}
+
+// getStepPositions returns the position of each step, which is the line of its 'uses' value or of the first line of
+// its 'run' script. Every step is looked up, so that identical steps get their own positions.
+func getStepPositions(positions *rule.Positions, steps []*step.Step) []glitch.Position {
+	stepPositions := make([]glitch.Position, len(steps))
+
+	for stepIdx, stepInstance := range steps {
+		if stepInstance.Uses != "" {
+			stepPositions[stepIdx] = positions.Next(stepInstance.Uses)
+
+			continue
+		}
+
+		firstLine, _, _ := strings.Cut(strings.TrimSpace(stepInstance.Run), "\n")
+		stepPositions[stepIdx] = positions.Next(firstLine)
+	}
+
+	return stepPositions
+}
+
+func isRunnerTemp(value string) bool {
+	return strings.Contains(value, "runner.temp") || strings.Contains(value, "RUNNER_TEMP")
+}
@@ -0,0 +1,115 @@
+package triggers
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/linter/ruletest"
+)
+
+func TestPrivilegedUntrustedCodeParseConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := PrivilegedUntrustedCode{}.ParseConfig(4)
+	if err == nil {
+		t.Errorf("PrivilegedUntrustedCode.ParseConfig should return error when conf is not bool")
+	}
+
+	conf, err := PrivilegedUntrustedCode{}.ParseConfig(true)
+	if err != nil || !conf {
+		t.Errorf("PrivilegedUntrustedCode.ParseConfig should return the value when conf is bool")
+	}
+}
+
+func TestPrivilegedUntrustedCode(t *testing.T) {
+	t.Parallel()
+
+	checker, _ := rule.NewChecker(PrivilegedUntrustedCode{}, true)
+	d := ruletest.GetDotGithub()
+
+	for workflowName, expected := range map[string][]string{
+		"triggers-pull-request-target.yml": {
+			"job 'build' step 1 checks out the pull request head",
+			"job 'build' step 2 runs code",
+			"job 'build' step 3 runs code",
+			"job 'build' step 3 passes secrets",
+			"job 'build' has secrets in job 'env'",
+		},
+		"triggers-workflow-run.yml": {
+			"job 'report' step 1 downloads artifacts",
+			"job 'report' step 3 checks out the pull request head",
+			"job 'report' step 4 runs code",
+			"job 'report' step 4 passes secrets",
+		},
+		"valid-workflow.yml": {},
+	} {
+		fn := func(f dotgithub.File, n string) {
+			compliant, ruleErrors, err := ruletest.Check(2, checker, f, d)
+			if compliant != (len(expected) == 0) {
+				t.Errorf("PrivilegedUntrustedCode.Check on %s should return %v", n, len(expected) == 0)
+			}
+
+			if err != nil {
+				t.Errorf("PrivilegedUntrustedCode.Check on %s failed with an error: %s", n, err.Error())
+			}
+
+			joinedErrors := strings.Join(ruleErrors, "\n")
+			if len(ruleErrors) != len(expected) {
+				t.Errorf(
+					"PrivilegedUntrustedCode.Check on %s should report %d errors, got [%s]",
+					n,
+					len(expected),
+					joinedErrors,
+				)
+			}
+
+			for _, e := range expected {
+				if !strings.Contains(joinedErrors, e) {
+					t.Errorf("PrivilegedUntrustedCode.Check on %s should report %s, got [%s]", n, e, joinedErrors)
+				}
+			}
+		}
+
+		ruletest.Workflow(d, workflowName, fn)
+	}
+}
+
+func TestPrivilegedUntrustedCodePositions(t *testing.T) {
+	t.Parallel()
+
+	checker, _ := rule.NewChecker(PrivilegedUntrustedCode{}, true)
+	steps := "    steps:\n" +
+		"      - uses: actions/checkout@v4\n" +
+		"      - uses: actions/checkout@v4\n        with:\n          ref: ${{ github.event.pull_request.head.sha }}\n" +
+		"      - run: make test\n"
+
+	d, err := dotgithub.NewFromFiles(context.Background(), map[string][]byte{
+		"workflows/main.yml": []byte(
+			"on: pull_request_target\njobs:\n" +
+				"  zeta:\n    runs-on: ubuntu-24.04\n" + steps +
+				"  alpha:\n    runs-on: ubuntu-24.04\n    env:\n      TOKEN: ${{ secrets.TOKEN }}\n" + steps,
+		),
+	})
+	if err != nil {
+		t.Fatalf("NewFromFiles failed with an error: %s", err.Error())
+	}
+
+	diagnostics, err := ruletest.Diagnostics(2, checker, d.Workflows["main.yml"], d)
+
+	lines := make([]int, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		lines = append(lines, diagnostic.Position.Line)
+
+		if diagnostic.Fix == nil || diagnostic.Fix.Message == "" {
+			t.Errorf("PrivilegedUntrustedCode.Check should suggest a fix for '%s'", diagnostic.Message)
+		}
+	}
+
+	if err != nil || !slices.Equal(lines, []int{7, 10, 17, 20, 20}) {
+		t.Errorf("PrivilegedUntrustedCode.Check should report the line of each step in file order, got %v and %v", lines, err)
+	}
+}
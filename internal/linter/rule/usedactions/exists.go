@@ -3,7 +3,6 @@ package usedactions
 import (
 	"errors"
 	"fmt"
-	"strings"
 
 	"octo-linter/internal/action"
 	"octo-linter/internal/dotgithub"
@@ -168,12 +167,10 @@ func (r Exists) processSteps(
 			continue
 		}
 
-		isLocal := regexpLocalAction.MatchString(step.Uses)
+		actionName, isLocal := dotgithub.GetLocalActionName(step.Uses)
 		isExternal := regexpExternalAction.MatchString(step.Uses)
 
 		if checkLocal && isLocal {
-			actionName := strings.ReplaceAll(step.Uses, "./.github/actions/", "")
-
 			actionInstance := dotGithub.GetAction(actionName)
 			if actionInstance == nil {
 				compliant = false
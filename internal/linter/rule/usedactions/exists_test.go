@@ -1,6 +1,7 @@
 package usedactions
 
 import (
+	"context"
 	"strings"
 	"testing"
 
@@ -142,3 +143,27 @@ func TestLocalExternal(t *testing.T) {
 		}
 	}
 }
+
+func TestLocalRootAction(t *testing.T) {
+	t.Parallel()
+
+	rule := Exists{FileTypeRequired: "workflow"}
+
+	d, err := dotgithub.NewFromFiles(context.Background(), map[string][]byte{
+		"workflows/main.yml": []byte("on: push\njobs:\n  main:\n    runs-on: ubuntu-24.04\n    steps:\n      - uses: ./\n"),
+	})
+	if err != nil {
+		t.Fatalf("NewFromFiles failed with an error: %s", err.Error())
+	}
+
+	compliant, ruleErrors, err := ruletest.Lint(3, rule, []interface{}{"local"}, d.Workflows["main.yml"], d)
+	if compliant || err != nil || len(ruleErrors) != 1 ||
+		!strings.Contains(ruleErrors[0], "calls non-existing local action '(root)'") {
+		t.Errorf(
+			"Exists.Lint should report a call to the root action when it is not loaded, got %v, %v and [%s]",
+			compliant,
+			err,
+			strings.Join(ruleErrors, "\n"),
+		)
+	}
+}
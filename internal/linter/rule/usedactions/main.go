@@ -20,6 +20,15 @@ const (
 	// ValueLocalOrExternal defines a configuration value for the referenced action (in 'uses' field) to be local or
 	// external.
 	ValueLocalOrExternal = "local-or-external"
+
+	// ValuePinnedSHA defines a configuration value for external actions to be pinned to a full commit SHA.
+	ValuePinnedSHA = "sha"
+	// ValuePinnedSHAOrSemverTag defines a configuration value for external actions to be pinned to a full commit SHA
+	// or to a full semantic version tag, eg. 'v4.1.2'.
+	ValuePinnedSHAOrSemverTag = "sha-or-semver-tag"
+	// ValuePinnedAny defines a configuration value for external actions to be referenced by any ref. Only branch refs
+	// are reported, as warnings.
+	ValuePinnedAny = "any"
 )
 
 var (
@@ -35,7 +44,14 @@ var (
 		ValueLocalOrExternal,
 		ValueExternalOnly,
 	)
-	errFileInvalidType = errors.New("file is of invalid type")
+	errValueNotPinnedMode = fmt.Errorf(
+		"value can be '%s', '%s' or '%s'",
+		ValuePinnedSHA,
+		ValuePinnedSHAOrSemverTag,
+		ValuePinnedAny,
+	)
+	errValueNotStringOrMap = errors.New("value should be string or map with 'mode' and 'trusted_owners' keys")
+	errFileInvalidType     = errors.New("file is of invalid type")
 )
 
 var (
@@ -71,12 +87,14 @@ func getStepsFromWorkflow(
 	steps := []*step.Step{}
 	msgPrefix := map[int]string{}
 
-	for jobName, job := range workflowInstance.Jobs {
+	// jobs are walked in the order they appear in the file, so that 'uses' lines are matched with their steps
+	for _, jobName := range workflowInstance.GetJobNames() {
+		job := workflowInstance.Jobs[jobName]
 		if len(job.Steps) == 0 {
 			continue
 		}
 
-		msgPrefix[len(steps)] = fmt.Sprintf("job '%s'", jobName)
+		msgPrefix[len(steps)] = fmt.Sprintf("job '%s' ", jobName)
 
 		steps = append(steps, job.Steps...)
 	}
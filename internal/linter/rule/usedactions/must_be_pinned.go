@@ -0,0 +1,303 @@
+package usedactions
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"octo-linter/internal/action"
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/glitch"
+	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/step"
+	"octo-linter/internal/workflow"
+)
+
+var (
+	regexpFullSHA        = regexp.MustCompile(`^[0-9a-f]{40}$`)
+	regexpSemverTag      = regexp.MustCompile(`^v?[0-9]+\.[0-9]+\.[0-9]+(-[0-9A-Za-z\.\-]+)?$`)
+	regexpVersionComment = regexp.MustCompile(`^v?[0-9]+(\.|$)`)
+	regexpUsesLine       = regexp.MustCompile(`^(\s*(?:-\s+)?uses:\s*['"]?)([^'"\s#]+)['"]?\s*(?:#\s*(.*?))?\s*$`)
+	branchRefsWarned     = []string{"main", "master", "develop", "dev", "trunk"}
+)
+
+// MustBePinnedConfig is the configuration of MustBePinned.
+type MustBePinnedConfig struct {
+	Mode string
+	// TrustedOwners contains owners, eg. 'actions', whose actions are not checked, except for branch refs.
+	TrustedOwners []string
+}
+
+// MustBePinned checks whether external actions used in steps are pinned to an immutable ref. Depending on the mode,
+// the ref must be a full commit SHA, a full commit SHA or a semantic version tag, or can be anything. Refs that look
+// like branches, eg. 'main', are always reported, also for trusted owners. A SHA can be followed by a '# vX.Y.Z'
+// comment with the version, and a shorter one, eg. '# v4', is reported as a warning.
+type MustBePinned struct {
+	FileTypeRequired string
+}
+
+// ConfigName returns the name of the rule as defined in the configuration file.
+func (r MustBePinned) ConfigName(t int) string {
+	switch t {
+	case rule.DotGithubFileTypeWorkflow:
+		return "used_actions_in_workflow_job_steps__must_be_pinned"
+	case rule.DotGithubFileTypeAction:
+		return "used_actions_in_action_steps__must_be_pinned"
+	default:
+		return "used_actions_in_*_steps__must_be_pinned"
+	}
+}
+
+// FileType returns an integer that specifies the file types (action and/or workflow) the rule targets.
+func (r MustBePinned) FileType() int {
+	return rule.GetFileTypeRequired(r.FileTypeRequired)
+}
+
+// ParseConfig checks whether the given value is valid for this rule's configuration and returns it as
+// MustBePinnedConfig. The value can be a mode, or a map with 'mode' and 'trusted_owners' keys.
+func (r MustBePinned) ParseConfig(conf interface{}) (MustBePinnedConfig, error) {
+	var parsedConf MustBePinnedConfig
+
+	switch confValue := conf.(type) {
+	case string:
+		parsedConf.Mode = confValue
+	case map[interface{}]interface{}:
+		for key, value := range confValue {
+			switch key {
+			case "mode":
+				mode, ok := value.(string)
+				if !ok {
+					return parsedConf, errValueNotString
+				}
+
+				parsedConf.Mode = mode
+			case "trusted_owners":
+				owners, ok := value.([]interface{})
+				if !ok {
+					return parsedConf, errValueNotStringArray
+				}
+
+				for _, owner := range owners {
+					ownerStr, ok := owner.(string)
+					if !ok {
+						return parsedConf, errValueNotStringArray
+					}
+
+					parsedConf.TrustedOwners = append(parsedConf.TrustedOwners, strings.ToLower(ownerStr))
+				}
+			default:
+				return parsedConf, errValueNotStringOrMap
+			}
+		}
+	default:
+		return parsedConf, errValueNotStringOrMap
+	}
+
+	if parsedConf.Mode != ValuePinnedSHA && parsedConf.Mode != ValuePinnedSHAOrSemverTag &&
+		parsedConf.Mode != ValuePinnedAny {
+		return parsedConf, errValueNotPinnedMode
+	}
+
+	return parsedConf, nil
+}
+
+// Check runs a rule with the specified configuration on a dotgithub.File (action or workflow), reports any errors
+// to the reporter, and returns whether the file is compliant.
+func (r MustBePinned) Check(
+	ctx context.Context,
+	conf MustBePinnedConfig,
+	file dotgithub.File,
+	_ *dotgithub.DotGithub,
+	reporter rule.Reporter,
+) (bool, error) {
+	compliant := true
+
+	err := forEachExternalUses(ctx, file, func(stepName string, uses string, line usesLine) {
+		diagnostic := r.checkUses(conf, uses, line)
+		if diagnostic == nil {
+			return
+		}
+
+		diagnostic.Message = stepName + " " + diagnostic.Message
+		diagnostic.Position = line.position
+
+		reporter.Report(*diagnostic)
+
+		if diagnostic.Severity != rule.SeverityWarning {
+			compliant = false
+		}
+	})
+
+	return compliant, err
+}
+
+// checkUses returns a diagnostic when the external action in 'uses' is not pinned as required, or nil.
+func (r MustBePinned) checkUses(conf MustBePinnedConfig, uses string, line usesLine) *rule.Diagnostic {
+	actionPath, ref, found := strings.Cut(uses, "@")
+	owner, _, _ := strings.Cut(actionPath, "/")
+
+	if found && slices.Contains(branchRefsWarned, ref) {
+		diagnostic := &rule.Diagnostic{
+			Message: fmt.Sprintf("calls action '%s' that refers to branch '%s', which can change at any time", uses, ref),
+			Fix:     &glitch.Fix{Message: fmt.Sprintf("pin '%s' to a commit SHA", actionPath)},
+		}
+
+		if conf.Mode == ValuePinnedAny {
+			diagnostic.Severity = rule.SeverityWarning
+		}
+
+		return diagnostic
+	}
+
+	if slices.Contains(conf.TrustedOwners, strings.ToLower(owner)) {
+		return nil
+	}
+
+	if !found || ref == "" {
+		return &rule.Diagnostic{
+			Message: fmt.Sprintf("calls action '%s' without a ref", uses),
+		}
+	}
+
+	if regexpFullSHA.MatchString(ref) {
+		return r.checkVersionComment(uses, line.comment)
+	}
+
+	if conf.Mode == ValuePinnedAny {
+		return nil
+	}
+
+	isSemverTag := regexpSemverTag.MatchString(ref)
+	if conf.Mode == ValuePinnedSHAOrSemverTag && isSemverTag {
+		return nil
+	}
+
+	expected := "a full commit SHA"
+	if conf.Mode == ValuePinnedSHAOrSemverTag {
+		expected = "a full commit SHA or a semantic version tag"
+	}
+
+	fixMessage := fmt.Sprintf("pin '%s' to %s", actionPath, expected)
+	if isSemverTag && conf.Mode == ValuePinnedSHA {
+		fixMessage = fmt.Sprintf(
+			"pin '%s' to the commit SHA of %s and keep the version in a '# %s' comment",
+			actionPath,
+			ref,
+			ref,
+		)
+	}
+
+	return &rule.Diagnostic{
+		Message: fmt.Sprintf("calls action '%s' that is not pinned to %s", uses, expected),
+		Fix:     &glitch.Fix{Message: fixMessage},
+	}
+}
+
+// checkVersionComment returns a warning when the comment after a commit SHA looks like a version, eg. 'v4', but is
+// not a full 'vX.Y.Z' version, or nil.
+func (r MustBePinned) checkVersionComment(uses string, comment string) *rule.Diagnostic {
+	if !regexpVersionComment.MatchString(comment) || regexpSemverTag.MatchString(comment) {
+		return nil
+	}
+
+	return &rule.Diagnostic{
+		Message:  fmt.Sprintf("calls action '%s' with version comment '%s' that is not in 'vX.Y.Z' format", uses, comment),
+		Severity: rule.SeverityWarning,
+	}
+}
+
+type usesLine struct {
+	position glitch.Position
+	// comment is the trailing comment, eg. the 'v4.1.2' version after a commit SHA.
+	comment string
+}
+
+// forEachExternalUses calls fn for each step of the action or workflow that calls an external action, with the name of
+// the step for messages, eg. "job 'build' step 2", the 'uses' value and its line in the file. It returns
+// errFileInvalidType when the file is neither an action nor a workflow.
+func forEachExternalUses(
+	ctx context.Context,
+	file dotgithub.File,
+	fn func(stepName string, uses string, line usesLine),
+) error {
+	var (
+		steps     []*step.Step
+		msgPrefix map[int]string
+		raw       []byte
+	)
+
+	switch fileInstance := file.(type) {
+	case *action.Action:
+		if fileInstance.Runs == nil || len(fileInstance.Runs.Steps) == 0 {
+			return nil
+		}
+
+		steps, msgPrefix, _, _, _ = getStepsFromAction(fileInstance)
+		raw = fileInstance.Raw
+	case *workflow.Workflow:
+		if len(fileInstance.Jobs) == 0 {
+			return nil
+		}
+
+		steps, msgPrefix, _, _, _ = getStepsFromWorkflow(fileInstance)
+		raw = fileInstance.Raw
+	default:
+		return errFileInvalidType
+	}
+
+	usesLines := getUsesLines(raw)
+	errPrefix := ""
+
+	for stepIdx, step := range steps {
+		if ctx.Err() != nil {
+			return ctx.Err() //nolint:wrapcheck
+		}
+
+		newErrPrefix, ok := msgPrefix[stepIdx]
+		if ok {
+			errPrefix = newErrPrefix
+		}
+
+		if step.Uses == "" || strings.HasPrefix(step.Uses, "./") || strings.HasPrefix(step.Uses, "docker://") {
+			continue
+		}
+
+		var line usesLine
+		if len(usesLines[step.Uses]) > 0 {
+			line = usesLines[step.Uses][0]
+			usesLines[step.Uses] = usesLines[step.Uses][1:]
+		}
+
+		fn(fmt.Sprintf("%sstep %d", errPrefix, stepIdx+1), step.Uses, line)
+	}
+
+	return nil
+}
+
+// getUsesLines returns the positions of 'uses' values in the file, in the order they appear, by value.
+func getUsesLines(raw []byte) map[string][]usesLine {
+	lines := map[string][]usesLine{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+
+		matches := regexpUsesLine.FindStringSubmatch(scanner.Text())
+		if matches == nil {
+			continue
+		}
+
+		lines[matches[2]] = append(lines[matches[2]], usesLine{
+			position: glitch.Position{Line: lineNum, Column: len(matches[1]) + 1},
+			comment:  matches[3],
+		})
+	}
+
+	return lines
+}
@@ -0,0 +1,205 @@
+package usedactions
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/linter/ruletest"
+)
+
+func TestMustBePinnedParseConfig(t *testing.T) {
+	t.Parallel()
+
+	r := MustBePinned{}
+
+	for _, confBad := range []interface{}{
+		4,
+		"wrong",
+		map[interface{}]interface{}{"mode": "wrong"},
+		map[interface{}]interface{}{"mode": ValuePinnedSHA, "trusted_owners": "actions"},
+		map[interface{}]interface{}{"mode": ValuePinnedSHA, "unknown": true},
+	} {
+		_, err := r.ParseConfig(confBad)
+		if err == nil {
+			t.Errorf("MustBePinned.ParseConfig should return error when conf is %v", confBad)
+		}
+	}
+
+	for _, confGood := range []interface{}{ValuePinnedSHA, ValuePinnedSHAOrSemverTag, ValuePinnedAny} {
+		_, err := r.ParseConfig(confGood)
+		if err != nil {
+			t.Errorf("MustBePinned.ParseConfig should not return error when conf is %v", confGood)
+		}
+	}
+
+	conf, err := r.ParseConfig(map[interface{}]interface{}{
+		"mode":           ValuePinnedSHA,
+		"trusted_owners": []interface{}{"Actions"},
+	})
+	if err != nil || conf.Mode != ValuePinnedSHA || len(conf.TrustedOwners) != 1 || conf.TrustedOwners[0] != "actions" {
+		t.Errorf("MustBePinned.ParseConfig should return mode and lowercase trusted owners, got %+v and %v", conf, err)
+	}
+}
+
+func TestMustBePinned(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		conf              interface{}
+		compliant         bool
+		numErrors         int
+		expectedInMessage string
+	}{
+		{
+			conf:              ValuePinnedSHA,
+			compliant:         false,
+			numErrors:         6,
+			expectedInMessage: "'actions/checkout@v4' that is not pinned to a full commit SHA",
+		},
+		{
+			conf: map[interface{}]interface{}{
+				"mode":           ValuePinnedSHA,
+				"trusted_owners": []interface{}{"actions"},
+			},
+			compliant:         false,
+			numErrors:         5,
+			expectedInMessage: "'org/repo/action@v4.1.2' that is not pinned to a full commit SHA",
+		},
+		{
+			conf:              map[interface{}]interface{}{"mode": ValuePinnedSHAOrSemverTag, "trusted_owners": []interface{}{"actions"}},
+			compliant:         false,
+			numErrors:         4,
+			expectedInMessage: "'org/repo@v4' that is not pinned to a full commit SHA or a semantic version tag",
+		},
+		{
+			conf:              ValuePinnedAny,
+			compliant:         true,
+			numErrors:         3,
+			expectedInMessage: "refers to branch 'main'",
+		},
+	}
+
+	for _, fileTypeRequired := range []string{"action", "workflow"} {
+		for _, tt := range tests {
+			checker, err := rule.NewChecker(MustBePinned{FileTypeRequired: fileTypeRequired}, tt.conf)
+			if err != nil {
+				t.Fatalf("NewChecker failed with an error: %s", err.Error())
+			}
+
+			d := ruletest.GetDotGithub()
+
+			fn := func(f dotgithub.File, n string) {
+				compliant, ruleErrors, err := ruletest.Check(2, checker, f, d)
+				if compliant != tt.compliant {
+					t.Errorf("MustBePinned.Check on %s should return %v when conf is %v", n, tt.compliant, tt.conf)
+				}
+
+				if err != nil {
+					t.Errorf("MustBePinned.Check on %s failed with an error: %s", n, err.Error())
+				}
+
+				joinedErrors := strings.Join(ruleErrors, "\n")
+				if len(ruleErrors) != tt.numErrors || !strings.Contains(joinedErrors, tt.expectedInMessage) {
+					t.Errorf(
+						"MustBePinned.Check on %s should report %d errors including '%s' when conf is %v, not [%s]",
+						n,
+						tt.numErrors,
+						tt.expectedInMessage,
+						tt.conf,
+						joinedErrors,
+					)
+				}
+
+				if !strings.Contains(joinedErrors, "version comment 'v4' that is not in 'vX.Y.Z' format") {
+					t.Errorf("MustBePinned.Check on %s should report a version comment that is not full", n)
+				}
+
+				if !strings.Contains(joinedErrors, "'actions/checkout@main' that refers to branch 'main'") {
+					t.Errorf("MustBePinned.Check on %s should report a branch ref of a trusted owner", n)
+				}
+
+				if fileTypeRequired == "workflow" && !strings.Contains(joinedErrors, "job 'main' step 6 calls") {
+					t.Errorf("MustBePinned.Check on %s should prefix errors with the job name", n)
+				}
+			}
+
+			if fileTypeRequired == "action" {
+				ruletest.Action(d, "usedactions-must-be-pinned", fn)
+			} else {
+				ruletest.Workflow(d, "usedactions-must-be-pinned.yml", fn)
+			}
+		}
+	}
+}
+
+func TestMustBePinnedPosition(t *testing.T) {
+	t.Parallel()
+
+	lines := getUsesLines([]byte("steps:\n  - uses: org/repo@main\n  - name: x\n    uses: 'org/repo@main' # v1.2.3\n"))
+
+	positions := lines["org/repo@main"]
+	if len(positions) != 2 || positions[0].position.Line != 2 || positions[0].position.Column != 11 ||
+		positions[1].position.Line != 4 || positions[1].comment != "v1.2.3" {
+		t.Errorf("getUsesLines should return positions and comments of 'uses' values, got %+v", positions)
+	}
+}
+
+func TestMustBePinnedLocalActions(t *testing.T) {
+	t.Parallel()
+
+	d, err := dotgithub.NewFromFiles(context.Background(), map[string][]byte{
+		"workflows/main.yml": []byte(
+			"name: Main\non: push\njobs:\n  main:\n    runs-on: ubuntu-latest\n    steps:\n" +
+				"      - uses: ./\n      - uses: ./tools/x\n      - uses: ./.github/actions/build\n" +
+				"      - uses: org/repo@main\n",
+		),
+	})
+	if err != nil {
+		t.Fatalf("NewFromFiles failed with an error: %s", err.Error())
+	}
+
+	checker, err := rule.NewChecker(MustBePinned{FileTypeRequired: "workflow"}, ValuePinnedSHA)
+	if err != nil {
+		t.Fatalf("NewChecker failed with an error: %s", err.Error())
+	}
+
+	_, ruleErrors, err := ruletest.Check(2, checker, d.Workflows["main.yml"], d)
+	if err != nil {
+		t.Fatalf("MustBePinned.Check failed with an error: %s", err.Error())
+	}
+
+	if len(ruleErrors) != 1 || !strings.Contains(ruleErrors[0], "'org/repo@main'") {
+		t.Errorf("MustBePinned.Check should skip local actions and report only 'org/repo@main', got %v", ruleErrors)
+	}
+}
+
+func TestMustBePinnedJobOrderPositions(t *testing.T) {
+	t.Parallel()
+
+	steps := "    steps:\n      - uses: org/repo@main\n"
+
+	d, err := dotgithub.NewFromFiles(context.Background(), map[string][]byte{
+		"workflows/main.yml": []byte(
+			"name: Main\non: push\njobs:\n  zeta:\n    runs-on: ubuntu-latest\n" + steps +
+				"  alpha:\n    runs-on: ubuntu-latest\n" + steps,
+		),
+	})
+	if err != nil {
+		t.Fatalf("NewFromFiles failed with an error: %s", err.Error())
+	}
+
+	checker, err := rule.NewChecker(MustBePinned{FileTypeRequired: "workflow"}, ValuePinnedSHA)
+	if err != nil {
+		t.Fatalf("NewChecker failed with an error: %s", err.Error())
+	}
+
+	diagnostics, err := ruletest.Diagnostics(2, checker, d.Workflows["main.yml"], d)
+	if err != nil || len(diagnostics) != 2 ||
+		!strings.HasPrefix(diagnostics[0].Message, "job 'zeta' ") || diagnostics[0].Position.Line != 7 ||
+		!strings.HasPrefix(diagnostics[1].Message, "job 'alpha' ") || diagnostics[1].Position.Line != 11 {
+		t.Errorf("MustBePinned.Check should report each job on its own line, got %+v and %v", diagnostics, err)
+	}
+}
@@ -0,0 +1,120 @@
+package usedactions
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"octo-linter/internal/advisory"
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/glitch"
+	"octo-linter/internal/linter/rule"
+)
+
+// MustNotHaveAdvisories checks whether external actions used in steps are not affected by advisories from a local
+// advisory file, eg. a compromised release. When an action is pinned to a commit SHA, the version from the comment
+// after it, eg. '# v4.1.2', is checked.
+type MustNotHaveAdvisories struct {
+	FileTypeRequired string
+	// ConfigDir is the directory of the configuration file, which a relative path to the advisory file is resolved
+	// against. It is empty for the default configuration, so the path is relative to the current directory.
+	ConfigDir string
+}
+
+// ConfigName returns the name of the rule as defined in the configuration file.
+func (r MustNotHaveAdvisories) ConfigName(t int) string {
+	switch t {
+	case rule.DotGithubFileTypeWorkflow:
+		return "used_actions_in_workflow_job_steps__must_not_have_advisories"
+	case rule.DotGithubFileTypeAction:
+		return "used_actions_in_action_steps__must_not_have_advisories"
+	default:
+		return "used_actions_in_*_steps__must_not_have_advisories"
+	}
+}
+
+// FileType returns an integer that specifies the file types (action and/or workflow) the rule targets.
+func (r MustNotHaveAdvisories) FileType() int {
+	return rule.GetFileTypeRequired(r.FileTypeRequired)
+}
+
+// ParseConfig checks whether the given value is valid for this rule's configuration, reads the advisory file it
+// points to, and returns the advisories. A relative path is resolved against ConfigDir, and the file is always read
+// from disk, also when linting a git revision or an archive. An empty string disables the rule.
+func (r MustNotHaveAdvisories) ParseConfig(conf interface{}) (advisory.Database, error) {
+	path, ok := conf.(string)
+	if !ok {
+		return advisory.Database{}, errValueNotString
+	}
+
+	if path == "" {
+		return advisory.Database{}, nil
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(r.ConfigDir, path)
+	}
+
+	database, err := advisory.Load(path)
+	if err != nil {
+		return database, fmt.Errorf("error loading advisories: %w", err)
+	}
+
+	return database, nil
+}
+
+// NotCacheable marks the rule as not cacheable, as the advisory file can change when the configuration does not.
+func (r MustNotHaveAdvisories) NotCacheable() {}
+
+// Check runs a rule with the specified configuration on a dotgithub.File (action or workflow), reports any errors
+// to the reporter, and returns whether the file is compliant.
+func (r MustNotHaveAdvisories) Check(
+	ctx context.Context,
+	conf advisory.Database,
+	file dotgithub.File,
+	_ *dotgithub.DotGithub,
+	reporter rule.Reporter,
+) (bool, error) {
+	if len(conf.Advisories) == 0 {
+		return true, nil
+	}
+
+	compliant := true
+
+	err := forEachExternalUses(ctx, file, func(stepName string, uses string, line usesLine) {
+		for _, found := range conf.Find(uses, line.comment) {
+			reporter.Report(rule.Diagnostic{
+				Message:  fmt.Sprintf("%s calls action '%s' %s", stepName, uses, getAdvisoryDetails(found)),
+				Position: line.position,
+				Fix:      &glitch.Fix{Message: getAdvisoryFixMessage(found)},
+			})
+
+			compliant = false
+		}
+	})
+
+	return compliant, err
+}
+
+// getAdvisoryDetails returns details of the advisory, eg. "affected by GHSA-xxxx (high): Summary".
+func getAdvisoryDetails(found advisory.Advisory) string {
+	details := "affected by " + found.ID
+	if found.Severity != "" {
+		details += fmt.Sprintf(" (%s)", found.Severity)
+	}
+
+	if found.Summary != "" {
+		details += ": " + found.Summary
+	}
+
+	return details
+}
+
+func getAdvisoryFixMessage(found advisory.Advisory) string {
+	message := fmt.Sprintf("use a version of '%s' that is not affected", found.Action)
+	if found.URL != "" {
+		message += ", see " + found.URL
+	}
+
+	return message
+}
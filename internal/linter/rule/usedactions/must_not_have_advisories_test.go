@@ -0,0 +1,93 @@
+package usedactions
+
+import (
+	"strings"
+	"testing"
+
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/linter/ruletest"
+)
+
+const advisoriesFile = "../../../../tests/advisories/advisories.yml"
+
+func TestMustNotHaveAdvisoriesParseConfig(t *testing.T) {
+	t.Parallel()
+
+	for _, confBad := range []interface{}{true, 4, "../../../../tests/advisories/missing.yml"} {
+		_, err := MustNotHaveAdvisories{}.ParseConfig(confBad)
+		if err == nil {
+			t.Errorf("MustNotHaveAdvisories.ParseConfig should return error when conf is %v", confBad)
+		}
+	}
+
+	conf, err := MustNotHaveAdvisories{}.ParseConfig(advisoriesFile)
+	if err != nil || len(conf.Advisories) != 2 {
+		t.Errorf("MustNotHaveAdvisories.ParseConfig should load advisories from the file, got %+v and %v", conf, err)
+	}
+
+	conf, err = MustNotHaveAdvisories{ConfigDir: "../../../../tests/advisories"}.ParseConfig("advisories.yml")
+	if err != nil || len(conf.Advisories) != 2 {
+		t.Errorf(
+			"MustNotHaveAdvisories.ParseConfig should load advisories relative to ConfigDir, got %+v and %v",
+			conf,
+			err,
+		)
+	}
+}
+
+func TestMustNotHaveAdvisories(t *testing.T) {
+	t.Parallel()
+
+	for _, fileTypeRequired := range []string{"action", "workflow"} {
+		checker, err := rule.NewChecker(MustNotHaveAdvisories{FileTypeRequired: fileTypeRequired}, advisoriesFile)
+		if err != nil {
+			t.Fatalf("NewChecker failed with an error: %s", err.Error())
+		}
+
+		d := ruletest.GetDotGithub()
+
+		expected := []string{
+			"step 2 calls action 'tj-actions/changed-files@v45' affected by GHSA-mrrh-fwg8-r2c3 (high): " +
+				"tj-actions/changed-files has been compromised",
+			"step 4 calls action 'tj-actions/changed-files@0e58ed8671d6b60d0890c21b07f8835ace038e67' affected by " +
+				"GHSA-mrrh-fwg8-r2c3",
+			"step 5 calls action 'tj-actions/changed-files@8e5e7e5ab8b370d6c329ec480221332ada57f0ab' affected by " +
+				"GHSA-mrrh-fwg8-r2c3",
+			"step 6 calls action 'org/repo/sub@v2.1.0' affected by GHSA-0000-0000-0001 (moderate): Test advisory",
+		}
+
+		fn := func(f dotgithub.File, n string) {
+			compliant, ruleErrors, err := ruletest.Check(2, checker, f, d)
+			if compliant {
+				t.Errorf("MustNotHaveAdvisories.Check on %s should return false when actions have advisories", n)
+			}
+
+			if err != nil {
+				t.Errorf("MustNotHaveAdvisories.Check on %s failed with an error: %s", n, err.Error())
+			}
+
+			joinedErrors := strings.Join(ruleErrors, "\n")
+			if len(ruleErrors) != len(expected) {
+				t.Errorf(
+					"MustNotHaveAdvisories.Check on %s should report %d errors, got [%s]",
+					n,
+					len(expected),
+					joinedErrors,
+				)
+			}
+
+			for _, e := range expected {
+				if !strings.Contains(joinedErrors, e) {
+					t.Errorf("MustNotHaveAdvisories.Check on %s should report %s, got [%s]", n, e, joinedErrors)
+				}
+			}
+		}
+
+		if fileTypeRequired == "action" {
+			ruletest.Action(d, "usedactions-must-not-have-advisories", fn)
+		} else {
+			ruletest.Workflow(d, "usedactions-must-not-have-advisories.yml", fn)
+		}
+	}
+}
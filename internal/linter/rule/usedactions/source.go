@@ -144,7 +144,7 @@ func (r Source) processSteps(
 			continue
 		}
 
-		isLocal := regexpLocalAction.MatchString(step.Uses)
+		_, isLocal := dotgithub.GetLocalActionName(step.Uses)
 		isExternal := regexpExternalAction.MatchString(step.Uses)
 
 		if confValue == ValueLocalOnly && !isLocal {
@@ -93,9 +93,9 @@ func TestExternalOnlyOnAction(t *testing.T) {
 				t.Errorf("Source.Lint on %s failed with an error: %s", n, err.Error())
 			}
 
-			if len(ruleErrors) != 2 {
+			if len(ruleErrors) != 3 {
 				t.Errorf(
-					"Source.Lint on %s should send 2 errors over the channel not [%s]",
+					"Source.Lint on %s should send 3 errors, including the root action, over the channel not [%s]",
 					n,
 					strings.Join(ruleErrors, "\n"),
 				)
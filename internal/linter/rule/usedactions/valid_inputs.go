@@ -134,7 +134,7 @@ func (r ValidInputs) processStepActionInputs(
 			continue
 		}
 
-		if step.With != nil && step.With[daInputName] != "" {
+		if step.GetWith(daInputName) != "" {
 			continue
 		}
 
@@ -153,7 +153,7 @@ func (r ValidInputs) processStepActionInputs(
 }
 
 func (r ValidInputs) processStepWith(
-	stepWith map[string]string,
+	stepWith map[string]interface{},
 	stepActionInputs map[string]*action.Input,
 	stepIdx int,
 	errPrefix string,
@@ -255,7 +255,13 @@ func (r ValidInputs) getStepFromStepUses(
 	if isLocal {
 		actionName := strings.ReplaceAll(stepUses, "./.github/actions/", "")
 
-		return dotGithub.GetAction(actionName)
+		// inputs of an action that could not be parsed are not known, and the action is reported as invalid YAML
+		actionInstance := dotGithub.GetAction(actionName)
+		if actionInstance != nil && dotGithub.GetParseError(actionInstance.Path) != nil {
+			return nil
+		}
+
+		return actionInstance
 	} else if isExternal {
 		return dotGithub.GetExternalAction(stepUses)
 	}
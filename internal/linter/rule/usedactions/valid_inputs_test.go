@@ -1,6 +1,7 @@
 package usedactions
 
 import (
+	"context"
 	"strings"
 	"testing"
 
@@ -90,3 +91,32 @@ func TestValidInputsCompliant(t *testing.T) {
 
 	ruletest.Workflow(d, "valid-workflow.yml", fn)
 }
+
+func TestValidInputsUnparsableAction(t *testing.T) {
+	t.Parallel()
+
+	rule := ValidInputs{
+		FileTypeRequired: "workflow",
+	}
+
+	d, err := dotgithub.NewFromFiles(context.Background(), map[string][]byte{
+		"actions/broken/action.yml": []byte("name: Broken\ninputs:\n  version: required: true\n"),
+		"workflows/main.yml": []byte(
+			"on: push\njobs:\n  main:\n    runs-on: ubuntu-latest\n    steps:\n" +
+				"      - uses: ./.github/actions/broken\n        with:\n          version: 1\n",
+		),
+	})
+	if err != nil {
+		t.Fatalf("NewFromFiles failed with an error: %s", err.Error())
+	}
+
+	compliant, ruleErrors, err := ruletest.Lint(2, rule, true, d.Workflows["main.yml"], d)
+	if !compliant || err != nil || len(ruleErrors) > 0 {
+		t.Errorf(
+			"ValidInputs.Lint should skip calls to an action that cannot be parsed, got %v, %v and %s",
+			compliant,
+			err,
+			strings.Join(ruleErrors, "|"),
+		)
+	}
+}
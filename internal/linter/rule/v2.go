@@ -0,0 +1,185 @@
+package rule
+
+import (
+	"context"
+	"fmt"
+
+	"octo-linter/internal/dotgithub"
+	"octo-linter/internal/linter/glitch"
+)
+
+// Severity of a diagnostic.
+type Severity int
+
+const (
+	// SeverityDefault reports a diagnostic as an error or as a warning, depending on whether the rule is listed in
+	// 'warning_only'.
+	SeverityDefault Severity = iota
+	// SeverityWarning always reports a diagnostic as a warning, eg. when a rule is not certain about it.
+	SeverityWarning
+)
+
+// Diagnostic is a single problem found by a rule in the linted file.
+type Diagnostic struct {
+	Message  string
+	Position glitch.Position
+	Severity Severity
+	Fix      *glitch.Fix
+	Related  []glitch.Location
+}
+
+// Reporter receives diagnostics from a rule. The linted file, its type and the rule name are filled in by the
+// Reporter so rules only describe the problem.
+type Reporter interface {
+	Report(diagnostic Diagnostic)
+}
+
+// RuleV2 represents a rule that gets its configuration already validated and converted to C. Check is only called
+// for file types returned by FileType so the rule does not need to check it again. It should return when ctx is
+// done.
+type RuleV2[C any] interface {
+	ParseConfig(conf interface{}) (C, error)
+	Check(
+		ctx context.Context,
+		conf C,
+		f dotgithub.File,
+		d *dotgithub.DotGithub,
+		reporter Reporter,
+	) (bool, error)
+	ConfigName(fileType int) string
+	FileType() int
+}
+
+// Checker is a rule with its configuration, as run by the linter. Both RuleV2 and Rule are run as a Checker.
+type Checker interface {
+	Check(ctx context.Context, f dotgithub.File, d *dotgithub.DotGithub, reporter Reporter) (bool, error)
+	ConfigName(fileType int) string
+	FileType() int
+	// ConfigValue returns the value from the configuration file that the rule was created with.
+	ConfigValue() interface{}
+	// IsCacheable returns false when the rule implements NotCacheable.
+	IsCacheable() bool
+}
+
+// NotCacheable is implemented by rules whose results depend on more than the linted files and the configuration,
+// eg. on the current date, so that they are run every time instead of having their results cached.
+type NotCacheable interface {
+	NotCacheable()
+}
+
+// NewChecker parses the configuration of a RuleV2 and returns it as a Checker.
+func NewChecker[C any](rule RuleV2[C], conf interface{}) (Checker, error) {
+	parsedConf, err := rule.ParseConfig(conf)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing config: %w", err)
+	}
+
+	return checker[C]{rule: rule, conf: parsedConf, value: conf}, nil
+}
+
+type checker[C any] struct {
+	rule  RuleV2[C]
+	conf  C
+	value interface{}
+}
+
+func (c checker[C]) Check(
+	ctx context.Context,
+	f dotgithub.File,
+	d *dotgithub.DotGithub,
+	reporter Reporter,
+) (bool, error) {
+	//nolint:wrapcheck // errors are wrapped by the linter
+	return c.rule.Check(ctx, c.conf, f, d, reporter)
+}
+
+func (c checker[C]) ConfigName(fileType int) string {
+	return c.rule.ConfigName(fileType)
+}
+
+func (c checker[C]) FileType() int {
+	return c.rule.FileType()
+}
+
+func (c checker[C]) ConfigValue() interface{} {
+	return c.value
+}
+
+func (c checker[C]) IsCacheable() bool {
+	_, ok := c.rule.(NotCacheable)
+
+	return !ok
+}
+
+// NewLegacyChecker validates the configuration of a Rule and returns it as a Checker, so that rules not migrated to
+// RuleV2 yet can be run by the linter. Glitches sent by the rule are reported as diagnostics with their text only,
+// as the remaining fields are filled in by the Reporter. The rule cannot be stopped when ctx is done.
+func NewLegacyChecker(rule Rule, conf interface{}) (Checker, error) {
+	err := rule.Validate(conf)
+	if err != nil {
+		return nil, fmt.Errorf("error validating config: %w", err)
+	}
+
+	return legacyChecker{rule: rule, conf: conf}, nil
+}
+
+type legacyChecker struct {
+	rule Rule
+	conf interface{}
+}
+
+func (c legacyChecker) Check(
+	_ context.Context,
+	f dotgithub.File,
+	d *dotgithub.DotGithub,
+	reporter Reporter,
+) (bool, error) {
+	compliant := true
+
+	var err error
+
+	chErrors := make(chan glitch.Glitch)
+
+	go func() {
+		compliant, err = c.rule.Lint(c.conf, f, d, chErrors)
+
+		close(chErrors)
+	}()
+
+	for glitchInstance := range chErrors {
+		reporter.Report(Diagnostic{Message: glitchInstance.ErrText})
+	}
+
+	return compliant, err
+}
+
+func (c legacyChecker) ConfigName(fileType int) string {
+	return c.rule.ConfigName(fileType)
+}
+
+func (c legacyChecker) FileType() int {
+	return c.rule.FileType()
+}
+
+func (c legacyChecker) ConfigValue() interface{} {
+	return c.conf
+}
+
+func (c legacyChecker) IsCacheable() bool {
+	_, ok := c.rule.(NotCacheable)
+
+	return !ok
+}
+
+// GetFileTypeRequired returns the file type for the 'FileTypeRequired' field of rules that are used for both actions
+// and workflows, or 0 when the name is not known.
+func GetFileTypeRequired(name string) int {
+	switch name {
+	case "action":
+		return DotGithubFileTypeAction
+	case "workflow":
+		return DotGithubFileTypeWorkflow
+	default:
+		return 0
+	}
+}
@@ -11,9 +11,11 @@ import (
 	"sync"
 	"time"
 
+	"octo-linter/internal/action"
 	"octo-linter/internal/dotgithub"
 	"octo-linter/internal/linter/glitch"
 	"octo-linter/internal/linter/rule"
+	"octo-linter/internal/workflow"
 )
 
 //nolint:gochecknoglobals
@@ -27,7 +29,9 @@ var errTimeout = errors.New("timeout")
 // GetDotGithub returns DitGithub with test rules.
 func GetDotGithub() *dotgithub.DotGithub {
 	testDotGithubOnce.Do(func() {
-		testDotGithub = &dotgithub.DotGithub{}
+		testDotGithub = &dotgithub.DotGithub{
+			RootActionPath: "../../../../tests/root-action",
+		}
 		logger := slog.New(slog.DiscardHandler)
 		slog.SetDefault(logger)
 
@@ -95,6 +99,69 @@ loop:
 	return compliant, ruleErrors, err
 }
 
+// Check runs a rule.Checker on a specified file and returns all diagnostics, formatted like in Lint, and a boolean
+// indicating whether it is compliant or not.
+func Check(
+	timeout int,
+	checker rule.Checker,
+	file dotgithub.File,
+	dotGithub *dotgithub.DotGithub,
+) (bool, []string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	reporter := &reporter{file: file, ruleName: checker.ConfigName(file.GetType())}
+
+	compliant, err := checker.Check(ctx, file, dotGithub, reporter)
+	if ctx.Err() != nil {
+		return false, reporter.ruleErrors, errTimeout
+	}
+
+	return compliant, reporter.ruleErrors, err //nolint:wrapcheck
+}
+
+// Diagnostics runs a rule.Checker on a specified file and returns all the diagnostics it reported, eg. to check
+// their positions.
+func Diagnostics(
+	timeout int,
+	checker rule.Checker,
+	file dotgithub.File,
+	dotGithub *dotgithub.DotGithub,
+) ([]rule.Diagnostic, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	reporter := &reporter{file: file, ruleName: checker.ConfigName(file.GetType())}
+
+	_, err := checker.Check(ctx, file, dotGithub, reporter)
+	if ctx.Err() != nil {
+		return reporter.diagnostics, errTimeout
+	}
+
+	return reporter.diagnostics, err //nolint:wrapcheck
+}
+
+type reporter struct {
+	file        dotgithub.File
+	ruleName    string
+	ruleErrors  []string
+	diagnostics []rule.Diagnostic
+}
+
+func (r *reporter) Report(diagnostic rule.Diagnostic) {
+	path := ""
+
+	switch fileInstance := r.file.(type) {
+	case *action.Action:
+		path = fileInstance.Path
+	case *workflow.Workflow:
+		path = fileInstance.Path
+	}
+
+	r.ruleErrors = append(r.ruleErrors, fmt.Sprintf("%s %s: %s", path, r.ruleName, diagnostic.Message))
+	r.diagnostics = append(r.diagnostics, diagnostic)
+}
+
 // Action runs a test function on a specific action in DotGithub.
 func Action(
 	dotGithub *dotgithub.DotGithub,
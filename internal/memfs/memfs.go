@@ -0,0 +1,212 @@
+// Package memfs implements a read-only fs.FS that keeps files in memory. It is used to lint files that do not come
+// from the local disk, eg. from a git revision or an archive.
+package memfs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"time"
+)
+
+const (
+	fileModeFile = 0o444
+	fileModeDir  = fs.ModeDir | 0o555
+)
+
+var (
+	errInvalidPath     = errors.New("invalid path")
+	errContentsMissing = errors.New("file contents are not available")
+)
+
+// FS is a read-only, in-memory fs.FS. Paths are slash-separated and relative to its root, as in fs.ValidPath.
+type FS struct {
+	files map[string][]byte
+	sizes map[string]int64
+	dirs  map[string][]fs.DirEntry
+}
+
+// New returns an empty FS.
+func New() *FS {
+	return &FS{
+		files: map[string][]byte{},
+		sizes: map[string]int64{},
+		dirs:  map[string][]fs.DirEntry{".": {}},
+	}
+}
+
+// AddFile adds a file with the given contents, creating its parent directories.
+func (f *FS) AddFile(name string, b []byte) error {
+	err := f.AddFileInfo(name, int64(len(b)))
+	if err != nil {
+		return err
+	}
+
+	f.files[name] = b
+
+	return nil
+}
+
+// AddFileInfo adds a file whose contents are not available. It can be listed and stat'ed but not read, which is
+// enough to check whether a file exists without loading it.
+func (f *FS) AddFileInfo(name string, size int64) error {
+	if !fs.ValidPath(name) || name == "." {
+		return fmt.Errorf("%w: %s", errInvalidPath, name)
+	}
+
+	if _, exists := f.dirs[name]; exists {
+		return fmt.Errorf("%w: %s is a directory", errInvalidPath, name)
+	}
+
+	for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+		if _, isFile := f.sizes[dir]; isFile {
+			return fmt.Errorf("%w: %s is a file", errInvalidPath, dir)
+		}
+	}
+
+	if _, exists := f.sizes[name]; exists {
+		f.sizes[name] = size
+		delete(f.files, name)
+
+		return nil
+	}
+
+	f.sizes[name] = size
+
+	entry := fs.FileInfoToDirEntry(&fileInfo{name: path.Base(name), size: size, mode: fileModeFile})
+
+	for dir := path.Dir(name); ; dir = path.Dir(dir) {
+		_, exists := f.dirs[dir]
+		f.dirs[dir] = append(f.dirs[dir], entry)
+
+		if exists || dir == "." {
+			return nil
+		}
+
+		entry = fs.FileInfoToDirEntry(&fileInfo{name: path.Base(dir), mode: fileModeDir})
+	}
+}
+
+// Open opens the named file or directory.
+func (f *FS) Open(name string) (fs.File, error) {
+	info, err := f.Stat(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: errors.Unwrap(err)}
+	}
+
+	if info.IsDir() {
+		entries, _ := f.ReadDir(name)
+
+		return &dirFile{info: info, entries: entries}, nil
+	}
+
+	b, err := f.ReadFile(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: errors.Unwrap(err)}
+	}
+
+	return &file{info: info, Reader: bytes.NewReader(b)}, nil
+}
+
+// ReadFile returns contents of the named file.
+func (f *FS) ReadFile(name string) ([]byte, error) {
+	if _, ok := f.sizes[name]; !ok {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+	}
+
+	b, ok := f.files[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: errContentsMissing}
+	}
+
+	return bytes.Clone(b), nil
+}
+
+// ReadDir returns entries of the named directory sorted by name.
+func (f *FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	entries, ok := f.dirs[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+
+	sorted := append([]fs.DirEntry{}, entries...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name() < sorted[j].Name()
+	})
+
+	return sorted, nil
+}
+
+// Stat returns fs.FileInfo of the named file or directory.
+func (f *FS) Stat(name string) (fs.FileInfo, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrInvalid}
+	}
+
+	if _, ok := f.dirs[name]; ok {
+		return &fileInfo{name: path.Base(name), mode: fileModeDir}, nil
+	}
+
+	if size, ok := f.sizes[name]; ok {
+		return &fileInfo{name: path.Base(name), size: size, mode: fileModeFile}, nil
+	}
+
+	return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+}
+
+type fileInfo struct {
+	name string
+	size int64
+	mode fs.FileMode
+}
+
+func (i *fileInfo) Name() string       { return i.name }
+func (i *fileInfo) Size() int64        { return i.size }
+func (i *fileInfo) Mode() fs.FileMode  { return i.mode }
+func (i *fileInfo) ModTime() time.Time { return time.Time{} }
+func (i *fileInfo) IsDir() bool        { return i.mode.IsDir() }
+func (i *fileInfo) Sys() any           { return nil }
+
+type file struct {
+	*bytes.Reader
+
+	info fs.FileInfo
+}
+
+func (f *file) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *file) Close() error               { return nil }
+
+type dirFile struct {
+	info    fs.FileInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *dirFile) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *dirFile) Close() error               { return nil }
+
+func (d *dirFile) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.Name(), Err: fs.ErrInvalid}
+}
+
+func (d *dirFile) ReadDir(count int) ([]fs.DirEntry, error) {
+	remaining := d.entries[d.offset:]
+	if count <= 0 {
+		d.offset = len(d.entries)
+
+		return remaining, nil
+	}
+
+	if len(remaining) == 0 {
+		return nil, io.EOF
+	}
+
+	count = min(count, len(remaining))
+	d.offset += count
+
+	return remaining[:count], nil
+}
@@ -0,0 +1,101 @@
+package memfs
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+)
+
+func TestFS(t *testing.T) {
+	t.Parallel()
+
+	fsys := New()
+
+	for name, b := range map[string]string{
+		"workflows/main.yml":       "name: Main\n",
+		"actions/build/action.yml": "name: Build\n",
+		"README.md":                "# Repo\n",
+	} {
+		err := fsys.AddFile(name, []byte(b))
+		if err != nil {
+			t.Fatalf("AddFile failed with an error: %s", err.Error())
+		}
+	}
+
+	err := fstest.TestFS(fsys, "workflows/main.yml", "actions/build/action.yml", "README.md")
+	if err != nil {
+		t.Errorf("FS should pass fstest.TestFS: %s", err.Error())
+	}
+
+	entries, err := fsys.ReadDir(".")
+	if err != nil || len(entries) != 3 || entries[0].Name() != "README.md" || !entries[1].IsDir() {
+		t.Errorf("ReadDir should return sorted entries of the root directory, got %v", entries)
+	}
+
+	b, err := fsys.ReadFile("actions/build/action.yml")
+	if err != nil || string(b) != "name: Build\n" {
+		t.Errorf("ReadFile should return contents of actions/build/action.yml, got error %v", err)
+	}
+
+	_, err = fsys.Stat("workflows/missing.yml")
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Stat should return fs.ErrNotExist for a missing file, got %v", err)
+	}
+}
+
+func TestFSFileInfo(t *testing.T) {
+	t.Parallel()
+
+	fsys := New()
+
+	err := fsys.AddFileInfo("dist/index.js", 1024)
+	if err != nil {
+		t.Fatalf("AddFileInfo failed with an error: %s", err.Error())
+	}
+
+	fileInfo, err := fsys.Stat("dist/index.js")
+	if err != nil || fileInfo.Size() != 1024 || fileInfo.IsDir() {
+		t.Errorf("Stat should return a 1024 byte file for dist/index.js, got error %v", err)
+	}
+
+	_, err = fsys.ReadFile("dist/index.js")
+	if !errors.Is(err, errContentsMissing) {
+		t.Errorf("ReadFile should return errContentsMissing for a file without contents, got %v", err)
+	}
+
+	err = fsys.AddFile("dist/index.js", []byte("console.log('build')\n"))
+	if err != nil {
+		t.Fatalf("AddFile failed with an error: %s", err.Error())
+	}
+
+	f, err := fsys.Open("dist/index.js")
+	if err != nil {
+		t.Fatalf("Open failed with an error: %s", err.Error())
+	}
+
+	b, err := io.ReadAll(f)
+	if err != nil || string(b) != "console.log('build')\n" {
+		t.Errorf("Open should return a file with contents added later, got error %v", err)
+	}
+
+	entries, _ := fsys.ReadDir("dist")
+	if len(entries) != 1 {
+		t.Errorf("AddFile should not add the same file twice to its directory, got %d entries", len(entries))
+	}
+}
+
+func TestFSInvalidPath(t *testing.T) {
+	t.Parallel()
+
+	fsys := New()
+	_ = fsys.AddFile("actions/build/action.yml", []byte("name: Build\n"))
+
+	for _, name := range []string{".", "", "../action.yml", "/action.yml", "actions/build", "actions/build/action.yml/x"} {
+		err := fsys.AddFile(name, nil)
+		if !errors.Is(err, errInvalidPath) {
+			t.Errorf("AddFile(%q) should return errInvalidPath, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+// Package profile records how long parts of a run take, eg. loading files, downloading external actions and running
+// each rule on each file, and reports the slowest of them.
+package profile
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Categories of spans.
+const (
+	CategoryLoad     = "load"
+	CategoryDownload = "download"
+	CategoryLint     = "lint"
+	CategoryRule     = "rule"
+)
+
+// DefaultReportLimit is the number of the slowest rules and files shown in the report.
+const DefaultReportLimit = 10
+
+// Span is a measured part of a run.
+type Span struct {
+	Category string
+	Name     string
+	// Path is the file the span is about, if any.
+	Path string
+	// Thread identifies the goroutine, eg. a lint worker, that the span ran on. It is used to lay out the trace.
+	Thread   int
+	Start    time.Time
+	Duration time.Duration
+}
+
+// Profiler collects spans. A nil Profiler can be used and does nothing, so that callers do not need to check whether
+// profiling is enabled.
+type Profiler struct {
+	mu    sync.Mutex
+	start time.Time
+	spans []Span
+}
+
+// New returns a Profiler that measures time from now.
+func New() *Profiler {
+	return &Profiler{start: time.Now()}
+}
+
+// Start starts a span and returns a function that ends it.
+func (p *Profiler) Start(category, name, path string, thread int) func() {
+	if p == nil {
+		return func() {}
+	}
+
+	start := time.Now()
+
+	return func() {
+		p.add(Span{
+			Category: category,
+			Name:     name,
+			Path:     path,
+			Thread:   thread,
+			Start:    start,
+			Duration: time.Since(start),
+		})
+	}
+}
+
+func (p *Profiler) add(span Span) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.spans = append(p.spans, span)
+}
+
+// Spans returns a copy of the spans collected so far, in the order they ended.
+func (p *Profiler) Spans() []Span {
+	if p == nil {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]Span{}, p.spans...)
+}
+
+type total struct {
+	name     string
+	count    int
+	duration time.Duration
+}
+
+// WriteReport writes totals for each category, the slowest rules and files, and totals for each rule group, which
+// is the part of the rule name before '__'.
+func (p *Profiler) WriteReport(w io.Writer, limit int) error {
+	if p == nil {
+		return nil
+	}
+
+	spans := p.Spans()
+	elapsed := time.Since(p.start)
+
+	categories := map[string]*total{}
+	rules := map[string]*total{}
+	files := map[string]*total{}
+	groups := map[string]*total{}
+
+	for _, span := range spans {
+		addTotal(categories, span.Category, span.Duration)
+
+		if span.Category != CategoryRule {
+			continue
+		}
+
+		addTotal(rules, span.Name, span.Duration)
+		addTotal(files, span.Path, span.Duration)
+		addTotal(groups, strings.SplitN(span.Name, "__", 2)[0], span.Duration) //nolint:mnd
+	}
+
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "profile: %s in total\n", formatDuration(elapsed))
+
+	for _, category := range []string{CategoryLoad, CategoryDownload, CategoryLint, CategoryRule} {
+		categoryTotal, ok := categories[category]
+		if !ok {
+			continue
+		}
+
+		fmt.Fprintf(
+			&builder,
+			"  %-10s %10s  %4d times\n",
+			category,
+			formatDuration(categoryTotal.duration),
+			categoryTotal.count,
+		)
+	}
+
+	writeTotals(&builder, "slowest rules", rules, limit)
+	writeTotals(&builder, "slowest files", files, limit)
+	writeTotals(&builder, "rule groups", groups, 0)
+
+	_, err := io.WriteString(w, builder.String())
+	if err != nil {
+		return fmt.Errorf("error writing profile report: %w", err)
+	}
+
+	return nil
+}
+
+func addTotal(totals map[string]*total, name string, duration time.Duration) {
+	if totals[name] == nil {
+		totals[name] = &total{name: name}
+	}
+
+	totals[name].count++
+	totals[name].duration += duration
+}
+
+// writeTotals writes totals from the slowest, up to limit of them. When limit is 0, all totals are written.
+func writeTotals(builder *strings.Builder, title string, totals map[string]*total, limit int) {
+	if len(totals) == 0 {
+		return
+	}
+
+	sorted := make([]*total, 0, len(totals))
+	for _, t := range totals {
+		sorted = append(sorted, t)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].duration != sorted[j].duration {
+			return sorted[i].duration > sorted[j].duration
+		}
+
+		return sorted[i].name < sorted[j].name
+	})
+
+	if limit > 0 && len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+
+	fmt.Fprintf(builder, "%s:\n", title)
+
+	for _, t := range sorted {
+		fmt.Fprintf(builder, "  %10s  %4d jobs  %s\n", formatDuration(t.duration), t.count, t.name)
+	}
+}
+
+func formatDuration(duration time.Duration) string {
+	return duration.Round(time.Microsecond).String()
+}
+
+// traceEvent is a complete event ("ph": "X") of the Chrome trace event format.
+type traceEvent struct {
+	Name      string            `json:"name"`
+	Category  string            `json:"cat"`
+	Phase     string            `json:"ph"`
+	Timestamp int64             `json:"ts"`
+	Duration  int64             `json:"dur"`
+	PID       int               `json:"pid"`
+	TID       int               `json:"tid"`
+	Args      map[string]string `json:"args,omitempty"`
+}
+
+// WriteTrace writes the spans in the Chrome trace event format, which can be opened in chrome://tracing or Perfetto.
+// Timestamps are in microseconds since the Profiler was created.
+func (p *Profiler) WriteTrace(w io.Writer) error {
+	if p == nil {
+		return nil
+	}
+
+	spans := p.Spans()
+	events := make([]traceEvent, 0, len(spans))
+
+	for _, span := range spans {
+		event := traceEvent{
+			Name:      span.Name,
+			Category:  span.Category,
+			Phase:     "X",
+			Timestamp: span.Start.Sub(p.start).Microseconds(),
+			Duration:  span.Duration.Microseconds(),
+			PID:       1,
+			TID:       span.Thread,
+		}
+
+		if span.Path != "" {
+			event.Args = map[string]string{"path": span.Path}
+		}
+
+		events = append(events, event)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp < events[j].Timestamp
+	})
+
+	err := json.NewEncoder(w).Encode(struct {
+		TraceEvents []traceEvent `json:"traceEvents"`
+	}{TraceEvents: events})
+	if err != nil {
+		return fmt.Errorf("error writing trace: %w", err)
+	}
+
+	return nil
+}
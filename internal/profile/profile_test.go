@@ -0,0 +1,78 @@
+package profile
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteReport(t *testing.T) {
+	t.Parallel()
+
+	p := New()
+	p.add(Span{Category: CategoryLoad, Name: "load", Duration: 30 * time.Millisecond})
+	p.add(Span{Category: CategoryRule, Name: "naming__a", Path: "main.yml", Duration: 5 * time.Millisecond})
+	p.add(Span{Category: CategoryRule, Name: "naming__b", Path: "main.yml", Duration: 2 * time.Millisecond})
+	p.add(Span{Category: CategoryRule, Name: "runners__c", Path: "other.yml", Duration: 1 * time.Millisecond})
+
+	buf := &bytes.Buffer{}
+
+	err := p.WriteReport(buf, 1)
+	if err != nil {
+		t.Fatalf("WriteReport failed with an error: %s", err.Error())
+	}
+
+	report := buf.String()
+
+	for _, expected := range []string{"5ms     1 jobs  naming__a", "7ms     2 jobs  main.yml", "7ms     2 jobs  naming\n"} {
+		if !strings.Contains(report, expected) {
+			t.Errorf("WriteReport should contain '%s', got:\n%s", expected, report)
+		}
+	}
+
+	if strings.Contains(report, "naming__b") || strings.Contains(report, "other.yml") {
+		t.Errorf("WriteReport should show only the slowest rule and file when limit is 1, got:\n%s", report)
+	}
+}
+
+func TestWriteTrace(t *testing.T) {
+	t.Parallel()
+
+	p := New()
+	p.Start(CategoryRule, "naming__a", "main.yml", 2)()
+
+	buf := &bytes.Buffer{}
+
+	err := p.WriteTrace(buf)
+	if err != nil {
+		t.Fatalf("WriteTrace failed with an error: %s", err.Error())
+	}
+
+	trace := struct {
+		TraceEvents []traceEvent `json:"traceEvents"`
+	}{}
+
+	err = json.Unmarshal(buf.Bytes(), &trace)
+	if err != nil || len(trace.TraceEvents) != 1 {
+		t.Fatalf("WriteTrace should write a single event as JSON, got %s", buf.String())
+	}
+
+	event := trace.TraceEvents[0]
+	if event.Phase != "X" || event.TID != 2 || event.Args["path"] != "main.yml" {
+		t.Errorf("WriteTrace should write a complete event with the thread and path, got %+v", event)
+	}
+}
+
+func TestNilProfiler(t *testing.T) {
+	t.Parallel()
+
+	var p *Profiler
+
+	p.Start(CategoryRule, "naming__a", "main.yml", 1)()
+
+	if p.Spans() != nil || p.WriteReport(&bytes.Buffer{}, 1) != nil || p.WriteTrace(&bytes.Buffer{}) != nil {
+		t.Errorf("nil Profiler should do nothing")
+	}
+}
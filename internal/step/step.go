@@ -1,14 +1,59 @@
 // Package step contains code related to steps in GitHub Actions workflows and actions.
 package step
 
-// Step represents a GitHub Actions step parsed from a workflow or action file.
+import (
+	"octo-linter/internal/yamlvalue"
+)
+
+// Step represents a GitHub Actions step parsed from a workflow or action file. Values in 'with' and 'env' keep the
+// type of the YAML scalar, eg. bool for true and string for 'true'.
 type Step struct {
-	ParentType string
-	Name       string            `yaml:"name"`
-	ID         string            `yaml:"id"`
-	Uses       string            `yaml:"uses"`
-	Shell      string            `yaml:"bash"`
-	Env        map[string]string `yaml:"env"`
-	Run        string            `yaml:"run"`
-	With       map[string]string `yaml:"with"`
+	ParentType       string
+	Name             string                 `yaml:"name"`
+	ID               string                 `yaml:"id"`
+	If               string                 `yaml:"if"`
+	Uses             string                 `yaml:"uses"`
+	Shell            string                 `yaml:"shell"`
+	WorkingDirectory string                 `yaml:"working-directory"`
+	Env              map[string]interface{} `yaml:"env"`
+	Run              string                 `yaml:"run"`
+	With             map[string]interface{} `yaml:"with"`
+	ContinueOnError  interface{}            `yaml:"continue-on-error"`
+	TimeoutMinutes   interface{}            `yaml:"timeout-minutes"`
+}
+
+// GetWith returns value of an input from the 'with' field as a string. It returns an empty string when the input
+// is not set.
+func (s *Step) GetWith(name string) string {
+	return yamlvalue.ToString(s.With[name])
+}
+
+// GetEnv returns value of an environment variable from the 'env' field as a string. It returns an empty string
+// when the variable is not set.
+func (s *Step) GetEnv(name string) string {
+	return yamlvalue.ToString(s.Env[name])
+}
+
+// IsWithString checks whether an input from the 'with' field is a YAML string, and not eg. a bool or a number.
+func (s *Step) IsWithString(name string) bool {
+	_, isString := s.With[name].(string)
+
+	return isString
+}
+
+// GetContinueOnError returns the 'continue-on-error' value. The second value is false when it is not set or it is
+// an expression.
+func (s *Step) GetContinueOnError() (bool, bool) {
+	return yamlvalue.ToBool(s.ContinueOnError)
+}
+
+// GetTimeoutMinutes returns the 'timeout-minutes' value. The second value is false when it is not set or it is an
+// expression.
+func (s *Step) GetTimeoutMinutes() (int, bool) {
+	return yamlvalue.ToInt(s.TimeoutMinutes)
+}
+
+// IsRun checks whether the step runs a script instead of calling an action.
+func (s *Step) IsRun() bool {
+	return s.Run != ""
 }
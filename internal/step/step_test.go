@@ -0,0 +1,142 @@
+package step
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func unmarshalStep(t *testing.T, s string) *Step {
+	t.Helper()
+
+	stepInstance := &Step{}
+
+	err := yaml.Unmarshal([]byte(s), stepInstance)
+	if err != nil {
+		t.Fatalf("cannot unmarshal step: %s", err.Error())
+	}
+
+	return stepInstance
+}
+
+func TestGetWithAndEnv(t *testing.T) {
+	t.Parallel()
+
+	stepInstance := unmarshalStep(t, `
+uses: actions/setup-node@v4
+with:
+  node-version: 20
+  cache: npm
+  check-latest: true
+  token: ${{ secrets.TOKEN }}
+env:
+  DEBUG: false
+  NAME: value
+`)
+
+	for name, expected := range map[string]string{
+		"node-version": "20",
+		"cache":        "npm",
+		"check-latest": "true",
+		"token":        "${{ secrets.TOKEN }}",
+		"missing":      "",
+	} {
+		if stepInstance.GetWith(name) != expected {
+			t.Errorf("Step.GetWith(%s) should return '%s', got '%s'", name, expected, stepInstance.GetWith(name))
+		}
+	}
+
+	for name, expected := range map[string]string{"DEBUG": "false", "NAME": "value", "MISSING": ""} {
+		if stepInstance.GetEnv(name) != expected {
+			t.Errorf("Step.GetEnv(%s) should return '%s', got '%s'", name, expected, stepInstance.GetEnv(name))
+		}
+	}
+
+	for name, expected := range map[string]bool{
+		"cache":        true,
+		"token":        true,
+		"node-version": false,
+		"check-latest": false,
+		"missing":      false,
+	} {
+		if stepInstance.IsWithString(name) != expected {
+			t.Errorf("Step.IsWithString(%s) should return %v", name, expected)
+		}
+	}
+
+	if stepInstance.IsRun() {
+		t.Errorf("Step.IsRun should return false for a step calling an action")
+	}
+}
+
+func TestGetContinueOnErrorAndTimeoutMinutes(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		yaml              string
+		continueOnError   bool
+		continueOnErrorOk bool
+		timeout           int
+		timeoutOk         bool
+	}{
+		{
+			yaml:              "run: make\ncontinue-on-error: true\ntimeout-minutes: 10",
+			continueOnError:   true,
+			continueOnErrorOk: true,
+			timeout:           10,
+			timeoutOk:         true,
+		},
+		{
+			yaml:              "run: make\ncontinue-on-error: 'false'\ntimeout-minutes: '5'",
+			continueOnError:   false,
+			continueOnErrorOk: true,
+			timeout:           5,
+			timeoutOk:         true,
+		},
+		{
+			yaml: "run: make\ncontinue-on-error: ${{ matrix.experimental }}\n" +
+				"timeout-minutes: ${{ inputs.timeout }}",
+			continueOnError:   false,
+			continueOnErrorOk: false,
+			timeout:           0,
+			timeoutOk:         false,
+		},
+		{
+			yaml:              "run: make",
+			continueOnError:   false,
+			continueOnErrorOk: false,
+			timeout:           0,
+			timeoutOk:         false,
+		},
+	} {
+		stepInstance := unmarshalStep(t, tt.yaml)
+
+		continueOnError, ok := stepInstance.GetContinueOnError()
+		if continueOnError != tt.continueOnError || ok != tt.continueOnErrorOk {
+			t.Errorf(
+				"Step.GetContinueOnError on '%s' should return %v, %v, got %v, %v",
+				tt.yaml,
+				tt.continueOnError,
+				tt.continueOnErrorOk,
+				continueOnError,
+				ok,
+			)
+		}
+
+		timeout, ok := stepInstance.GetTimeoutMinutes()
+		if timeout != tt.timeout || ok != tt.timeoutOk {
+			t.Errorf(
+				"Step.GetTimeoutMinutes on '%s' should return %v, %v, got %v, %v",
+				tt.yaml,
+				tt.timeout,
+				tt.timeoutOk,
+				timeout,
+				ok,
+			)
+		}
+
+		if !stepInstance.IsRun() {
+			t.Errorf("Step.IsRun on '%s' should return true", tt.yaml)
+		}
+	}
+}
@@ -2,5 +2,19 @@ package workflow
 
 // Call represents a 'workflow_call' field in a GitHub Actions workflow parsed from YAML.
 type Call struct {
-	Inputs map[string]*Input `yaml:"inputs"`
+	Inputs  map[string]*Input      `yaml:"inputs"`
+	Outputs map[string]*CallOutput `yaml:"outputs"`
+	Secrets map[string]*CallSecret `yaml:"secrets"`
+}
+
+// CallOutput represents an output of a reusable workflow, defined in 'workflow_call'.
+type CallOutput struct {
+	Description string `yaml:"description"`
+	Value       string `yaml:"value"`
+}
+
+// CallSecret represents a secret of a reusable workflow, defined in 'workflow_call'.
+type CallSecret struct {
+	Description string `yaml:"description"`
+	Required    bool   `yaml:"required"`
 }
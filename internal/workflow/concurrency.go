@@ -0,0 +1,31 @@
+package workflow
+
+import "octo-linter/internal/yamlvalue"
+
+// Concurrency represents a 'concurrency' field of a workflow or a job. It is either a group name or a mapping with
+// the group name and 'cancel-in-progress'.
+type Concurrency struct {
+	Group            string
+	CancelInProgress string
+}
+
+func newConcurrency(value interface{}) *Concurrency {
+	if value == nil {
+		return nil
+	}
+
+	group, isString := value.(string)
+	if isString {
+		return &Concurrency{Group: group}
+	}
+
+	fields := yamlvalue.ToStringMap(value)
+	if fields == nil {
+		return nil
+	}
+
+	return &Concurrency{
+		Group:            yamlvalue.ToString(fields["group"]),
+		CancelInProgress: yamlvalue.ToString(fields["cancel-in-progress"]),
+	}
+}
@@ -0,0 +1,55 @@
+package workflow
+
+import "octo-linter/internal/yamlvalue"
+
+// Container represents a 'container' field of a job or a service in its 'services' field. It is either an image
+// name or a mapping with the image and its settings.
+type Container struct {
+	Image       string
+	Credentials map[string]string
+	Env         map[string]string
+	Ports       []string
+	Volumes     []string
+	Options     string
+}
+
+func newContainer(value interface{}) *Container {
+	if value == nil {
+		return nil
+	}
+
+	image, isString := value.(string)
+	if isString {
+		return &Container{Image: image}
+	}
+
+	fields := yamlvalue.ToStringMap(value)
+	if fields == nil {
+		return nil
+	}
+
+	container := &Container{
+		Image:   yamlvalue.ToString(fields["image"]),
+		Ports:   yamlvalue.ToStringSlice(fields["ports"]),
+		Volumes: yamlvalue.ToStringSlice(fields["volumes"]),
+		Options: yamlvalue.ToString(fields["options"]),
+	}
+
+	credentials := yamlvalue.ToStringMap(fields["credentials"])
+	if credentials != nil {
+		container.Credentials = make(map[string]string, len(credentials))
+		for k, v := range credentials {
+			container.Credentials[k] = yamlvalue.ToString(v)
+		}
+	}
+
+	env := yamlvalue.ToStringMap(fields["env"])
+	if env != nil {
+		container.Env = make(map[string]string, len(env))
+		for k, v := range env {
+			container.Env[k] = yamlvalue.ToString(v)
+		}
+	}
+
+	return container
+}
@@ -0,0 +1,12 @@
+package workflow
+
+// Defaults represents a 'defaults' field of a workflow or a job.
+type Defaults struct {
+	Run *DefaultsRun `yaml:"run"`
+}
+
+// DefaultsRun represents a 'defaults.run' field of a workflow or a job.
+type DefaultsRun struct {
+	Shell            string `yaml:"shell"`
+	WorkingDirectory string `yaml:"working-directory"`
+}
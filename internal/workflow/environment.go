@@ -0,0 +1,31 @@
+package workflow
+
+import "octo-linter/internal/yamlvalue"
+
+// Environment represents an 'environment' field of a job. It is either an environment name or a mapping with the
+// name and URL.
+type Environment struct {
+	Name string
+	URL  string
+}
+
+func newEnvironment(value interface{}) *Environment {
+	if value == nil {
+		return nil
+	}
+
+	name, isString := value.(string)
+	if isString {
+		return &Environment{Name: name}
+	}
+
+	fields := yamlvalue.ToStringMap(value)
+	if fields == nil {
+		return nil
+	}
+
+	return &Environment{
+		Name: yamlvalue.ToString(fields["name"]),
+		URL:  yamlvalue.ToString(fields["url"]),
+	}
+}
@@ -1,8 +1,22 @@
 package workflow
 
+const (
+	// InputTypeString is the type of an input that accepts any text.
+	InputTypeString = "string"
+	// InputTypeChoice is the type of a 'workflow_dispatch' input that accepts one of the 'options'.
+	InputTypeChoice = "choice"
+)
+
 // Input represents an input of a GitHub Actions workflow parsed from YAML.
 type Input struct {
-	Description string `yaml:"description"`
-	Default     string `yaml:"default"`
-	Required    bool   `yaml:"required"`
+	Description string   `yaml:"description"`
+	Default     string   `yaml:"default"`
+	Required    bool     `yaml:"required"`
+	Type        string   `yaml:"type"`
+	Options     []string `yaml:"options"`
+}
+
+// IsFreeText checks whether the input accepts any text. Inputs without a type are strings.
+func (i *Input) IsFreeText() bool {
+	return i.Type == "" || i.Type == InputTypeString
 }
@@ -2,16 +2,33 @@ package workflow
 
 import (
 	"octo-linter/internal/step"
+	"octo-linter/internal/yamlvalue"
 )
 
+// SecretsInherit is a value of job's 'secrets' field that passes all the secrets to the called workflow.
+const SecretsInherit = "inherit"
+
 // Job represents a job in a GitHub Actions workflow parsed from YAML.
 type Job struct {
-	Name   string            `yaml:"name"`
-	Uses   string            `yaml:"uses"`
-	RunsOn interface{}       `yaml:"runs-on"`
-	Steps  []*step.Step      `yaml:"steps"`
-	Env    map[string]string `yaml:"env"`
-	Needs  interface{}       `yaml:"needs,omitempty"`
+	Name            string                 `yaml:"name"`
+	Uses            string                 `yaml:"uses"`
+	RunsOn          interface{}            `yaml:"runs-on"`
+	Steps           []*step.Step           `yaml:"steps"`
+	Env             map[string]string      `yaml:"env"`
+	Needs           interface{}            `yaml:"needs,omitempty"`
+	If              string                 `yaml:"if"`
+	Permissions     interface{}            `yaml:"permissions"`
+	Environment     interface{}            `yaml:"environment"`
+	Concurrency     interface{}            `yaml:"concurrency"`
+	Defaults        *Defaults              `yaml:"defaults"`
+	Strategy        *Strategy              `yaml:"strategy"`
+	Outputs         map[string]string      `yaml:"outputs"`
+	TimeoutMinutes  interface{}            `yaml:"timeout-minutes"`
+	ContinueOnError interface{}            `yaml:"continue-on-error"`
+	Container       interface{}            `yaml:"container"`
+	Services        map[string]interface{} `yaml:"services"`
+	With            map[string]interface{} `yaml:"with"`
+	Secrets         interface{}            `yaml:"secrets"`
 }
 
 // SetParentType sets parent type for all the steps.
@@ -20,3 +37,86 @@ func (wj *Job) SetParentType(t string) {
 		s.ParentType = t
 	}
 }
+
+// GetRunsOn returns the parsed 'runs-on' field, or nil when it is not defined.
+func (wj *Job) GetRunsOn() *RunsOn {
+	return newRunsOn(wj.RunsOn)
+}
+
+// GetNeeds returns names of the jobs from the 'needs' field.
+func (wj *Job) GetNeeds() []string {
+	return yamlvalue.ToStringSlice(wj.Needs)
+}
+
+// GetPermissions returns the parsed 'permissions' field, or nil when it is not defined.
+func (wj *Job) GetPermissions() *Permissions {
+	return newPermissions(wj.Permissions)
+}
+
+// GetEnvironment returns the parsed 'environment' field, or nil when it is not defined.
+func (wj *Job) GetEnvironment() *Environment {
+	return newEnvironment(wj.Environment)
+}
+
+// GetConcurrency returns the parsed 'concurrency' field, or nil when it is not defined.
+func (wj *Job) GetConcurrency() *Concurrency {
+	return newConcurrency(wj.Concurrency)
+}
+
+// GetTimeoutMinutes returns the 'timeout-minutes' value. The second value is false when it is not set or it is an
+// expression.
+func (wj *Job) GetTimeoutMinutes() (int, bool) {
+	return yamlvalue.ToInt(wj.TimeoutMinutes)
+}
+
+// GetContinueOnError returns the 'continue-on-error' value. The second value is false when it is not set or it is
+// an expression.
+func (wj *Job) GetContinueOnError() (bool, bool) {
+	return yamlvalue.ToBool(wj.ContinueOnError)
+}
+
+// GetContainer returns the parsed 'container' field, or nil when it is not defined.
+func (wj *Job) GetContainer() *Container {
+	return newContainer(wj.Container)
+}
+
+// GetServices returns the parsed 'services' field.
+func (wj *Job) GetServices() map[string]*Container {
+	if len(wj.Services) == 0 {
+		return nil
+	}
+
+	services := make(map[string]*Container, len(wj.Services))
+	for name, service := range wj.Services {
+		services[name] = newContainer(service)
+	}
+
+	return services
+}
+
+// IsSecretsInherit checks whether the job passes all the secrets to the called workflow.
+func (wj *Job) IsSecretsInherit() bool {
+	secrets, isString := wj.Secrets.(string)
+
+	return isString && secrets == SecretsInherit
+}
+
+// GetSecrets returns secrets explicitly passed to the called workflow.
+func (wj *Job) GetSecrets() map[string]string {
+	fields := yamlvalue.ToStringMap(wj.Secrets)
+	if fields == nil {
+		return nil
+	}
+
+	secrets := make(map[string]string, len(fields))
+	for name, value := range fields {
+		secrets[name] = yamlvalue.ToString(value)
+	}
+
+	return secrets
+}
+
+// IsCallingWorkflow checks whether the job calls a reusable workflow instead of running steps.
+func (wj *Job) IsCallingWorkflow() bool {
+	return wj.Uses != ""
+}
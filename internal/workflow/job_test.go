@@ -0,0 +1,260 @@
+package workflow
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func unmarshalJob(t *testing.T, s string) *Job {
+	t.Helper()
+
+	job := &Job{}
+
+	err := yaml.Unmarshal([]byte(s), job)
+	if err != nil {
+		t.Fatalf("cannot unmarshal job: %s", err.Error())
+	}
+
+	return job
+}
+
+func TestGetRunsOn(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		yaml     string
+		expected *RunsOn
+	}{
+		{yaml: "steps: []", expected: nil},
+		{yaml: "runs-on: ubuntu-latest", expected: &RunsOn{Labels: []string{"ubuntu-latest"}}},
+		{yaml: "runs-on: ${{ matrix.os }}", expected: &RunsOn{Labels: []string{"${{ matrix.os }}"}}},
+		{
+			yaml:     "runs-on: [self-hosted, linux, x64]",
+			expected: &RunsOn{Labels: []string{"self-hosted", "linux", "x64"}},
+		},
+		{
+			yaml:     "runs-on:\n  group: large-runners\n  labels: [linux, gpu]",
+			expected: &RunsOn{Group: "large-runners", Labels: []string{"linux", "gpu"}},
+		},
+		{
+			yaml:     "runs-on:\n  group: large-runners\n  labels: linux",
+			expected: &RunsOn{Group: "large-runners", Labels: []string{"linux"}},
+		},
+		{yaml: "runs-on:\n  group: large-runners", expected: &RunsOn{Group: "large-runners"}},
+	} {
+		result := unmarshalJob(t, tt.yaml).GetRunsOn()
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Errorf("Job.GetRunsOn on '%s' should return %+v, got %+v", tt.yaml, tt.expected, result)
+		}
+	}
+}
+
+func TestGetNeeds(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		yaml     string
+		expected []string
+	}{
+		{yaml: "runs-on: ubuntu-latest", expected: nil},
+		{yaml: "needs: build", expected: []string{"build"}},
+		{yaml: "needs: [build, test]", expected: []string{"build", "test"}},
+	} {
+		result := unmarshalJob(t, tt.yaml).GetNeeds()
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Errorf("Job.GetNeeds on '%s' should return %v, got %v", tt.yaml, tt.expected, result)
+		}
+	}
+}
+
+func TestGetEnvironment(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		yaml     string
+		expected *Environment
+	}{
+		{yaml: "runs-on: ubuntu-latest", expected: nil},
+		{yaml: "environment: production", expected: &Environment{Name: "production"}},
+		{yaml: "environment: ${{ inputs.environment }}", expected: &Environment{Name: "${{ inputs.environment }}"}},
+		{
+			yaml:     "environment:\n  name: production\n  url: https://example.com",
+			expected: &Environment{Name: "production", URL: "https://example.com"},
+		},
+		{yaml: "environment: [production]", expected: nil},
+	} {
+		result := unmarshalJob(t, tt.yaml).GetEnvironment()
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Errorf("Job.GetEnvironment on '%s' should return %+v, got %+v", tt.yaml, tt.expected, result)
+		}
+	}
+}
+
+func TestGetConcurrency(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		yaml     string
+		expected *Concurrency
+	}{
+		{yaml: "runs-on: ubuntu-latest", expected: nil},
+		{yaml: "concurrency: deploy", expected: &Concurrency{Group: "deploy"}},
+		{
+			yaml:     "concurrency:\n  group: ${{ github.ref }}\n  cancel-in-progress: true",
+			expected: &Concurrency{Group: "${{ github.ref }}", CancelInProgress: "true"},
+		},
+		{
+			yaml: "concurrency:\n  group: deploy\n  cancel-in-progress: ${{ github.ref != 'refs/heads/main' }}",
+			expected: &Concurrency{
+				Group:            "deploy",
+				CancelInProgress: "${{ github.ref != 'refs/heads/main' }}",
+			},
+		},
+	} {
+		result := unmarshalJob(t, tt.yaml).GetConcurrency()
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Errorf("Job.GetConcurrency on '%s' should return %+v, got %+v", tt.yaml, tt.expected, result)
+		}
+	}
+}
+
+func TestGetContainerAndServices(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		yaml     string
+		expected *Container
+	}{
+		{yaml: "runs-on: ubuntu-latest", expected: nil},
+		{yaml: "container: node:20", expected: &Container{Image: "node:20"}},
+		{yaml: "container: ${{ matrix.image }}", expected: &Container{Image: "${{ matrix.image }}"}},
+		{
+			yaml: "container:\n  image: ghcr.io/org/image:1\n  credentials:\n    username: ${{ github.actor }}\n" +
+				"    password: ${{ secrets.TOKEN }}\n  env:\n    NODE_ENV: development\n    DEBUG: true\n" +
+				"  ports: [80, '443:443']\n  volumes: my_docker_volume:/volume_mount\n  options: --cpus 1",
+			expected: &Container{
+				Image:       "ghcr.io/org/image:1",
+				Credentials: map[string]string{"username": "${{ github.actor }}", "password": "${{ secrets.TOKEN }}"},
+				Env:         map[string]string{"NODE_ENV": "development", "DEBUG": "true"},
+				Ports:       []string{"80", "443:443"},
+				Volumes:     []string{"my_docker_volume:/volume_mount"},
+				Options:     "--cpus 1",
+			},
+		},
+		{yaml: "container: [node]", expected: nil},
+	} {
+		result := unmarshalJob(t, tt.yaml).GetContainer()
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Errorf("Job.GetContainer on '%s' should return %+v, got %+v", tt.yaml, tt.expected, result)
+		}
+	}
+
+	services := unmarshalJob(t, "services:\n  redis: redis:7\n  postgres:\n    image: postgres:16\n    ports: [5432]").
+		GetServices()
+
+	expected := map[string]*Container{
+		"redis":    {Image: "redis:7"},
+		"postgres": {Image: "postgres:16", Ports: []string{"5432"}},
+	}
+	if !reflect.DeepEqual(services, expected) {
+		t.Errorf("Job.GetServices should return %+v, got %+v", expected, services)
+	}
+
+	if unmarshalJob(t, "runs-on: ubuntu-latest").GetServices() != nil {
+		t.Errorf("Job.GetServices should return nil when 'services' is not set")
+	}
+}
+
+func TestGetTimeoutMinutesAndContinueOnError(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		yaml              string
+		timeout           int
+		timeoutOk         bool
+		continueOnError   bool
+		continueOnErrorOk bool
+	}{
+		{yaml: "runs-on: ubuntu-latest"},
+		{
+			yaml:              "timeout-minutes: 30\ncontinue-on-error: true",
+			timeout:           30,
+			timeoutOk:         true,
+			continueOnError:   true,
+			continueOnErrorOk: true,
+		},
+		{
+			yaml:              "timeout-minutes: '15'\ncontinue-on-error: 'false'",
+			timeout:           15,
+			timeoutOk:         true,
+			continueOnError:   false,
+			continueOnErrorOk: true,
+		},
+		{yaml: "timeout-minutes: ${{ inputs.timeout }}\ncontinue-on-error: ${{ matrix.experimental }}"},
+	} {
+		job := unmarshalJob(t, tt.yaml)
+
+		timeout, ok := job.GetTimeoutMinutes()
+		if timeout != tt.timeout || ok != tt.timeoutOk {
+			t.Errorf("Job.GetTimeoutMinutes on '%s' should return %v, %v, got %v, %v", tt.yaml, tt.timeout, tt.timeoutOk, timeout, ok)
+		}
+
+		continueOnError, ok := job.GetContinueOnError()
+		if continueOnError != tt.continueOnError || ok != tt.continueOnErrorOk {
+			t.Errorf(
+				"Job.GetContinueOnError on '%s' should return %v, %v, got %v, %v",
+				tt.yaml,
+				tt.continueOnError,
+				tt.continueOnErrorOk,
+				continueOnError,
+				ok,
+			)
+		}
+	}
+}
+
+func TestGetSecrets(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		yaml      string
+		isInherit bool
+		isCalling bool
+		secrets   map[string]string
+	}{
+		{yaml: "runs-on: ubuntu-latest"},
+		{yaml: "uses: ./.github/workflows/called.yml\nsecrets: inherit", isInherit: true, isCalling: true},
+		{
+			yaml:      "uses: org/repo/.github/workflows/called.yml@v1\nsecrets:\n  token: ${{ secrets.TOKEN }}",
+			isCalling: true,
+			secrets:   map[string]string{"token": "${{ secrets.TOKEN }}"},
+		},
+	} {
+		job := unmarshalJob(t, tt.yaml)
+
+		if job.IsSecretsInherit() != tt.isInherit || job.IsCallingWorkflow() != tt.isCalling {
+			t.Errorf(
+				"Job on '%s' should have IsSecretsInherit %v and IsCallingWorkflow %v",
+				tt.yaml,
+				tt.isInherit,
+				tt.isCalling,
+			)
+		}
+
+		if !reflect.DeepEqual(job.GetSecrets(), tt.secrets) {
+			t.Errorf("Job.GetSecrets on '%s' should return %v, got %v", tt.yaml, tt.secrets, job.GetSecrets())
+		}
+	}
+}
+
+func TestDefaults(t *testing.T) {
+	t.Parallel()
+
+	job := unmarshalJob(t, "defaults:\n  run:\n    shell: bash\n    working-directory: ./app")
+	if job.Defaults == nil || job.Defaults.Run == nil || job.Defaults.Run.Shell != "bash" ||
+		job.Defaults.Run.WorkingDirectory != "./app" {
+		t.Errorf("Job.Defaults should have the shell and the working directory, got %+v", job.Defaults)
+	}
+}
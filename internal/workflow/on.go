@@ -1,7 +1,190 @@
 package workflow
 
-// On represents a 'on' field in a GitHub Actions workflow parsed from YAML.
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"octo-linter/internal/yamlvalue"
+)
+
+var errOnInvalidType = errors.New("'on' should be a string, a list or a mapping")
+
+// Names of the events that trigger a workflow.
+const (
+	EventBranchProtectionRule     = "branch_protection_rule"
+	EventCheckRun                 = "check_run"
+	EventCheckSuite               = "check_suite"
+	EventCreate                   = "create"
+	EventDelete                   = "delete"
+	EventDeployment               = "deployment"
+	EventDeploymentStatus         = "deployment_status"
+	EventDiscussion               = "discussion"
+	EventDiscussionComment        = "discussion_comment"
+	EventFork                     = "fork"
+	EventGollum                   = "gollum"
+	EventImageVersion             = "image_version"
+	EventIssueComment             = "issue_comment"
+	EventIssues                   = "issues"
+	EventLabel                    = "label"
+	EventMergeGroup               = "merge_group"
+	EventMilestone                = "milestone"
+	EventPageBuild                = "page_build"
+	EventPublic                   = "public"
+	EventPullRequest              = "pull_request"
+	EventPullRequestReview        = "pull_request_review"
+	EventPullRequestReviewComment = "pull_request_review_comment"
+	EventPullRequestTarget        = "pull_request_target"
+	EventPush                     = "push"
+	EventRegistryPackage          = "registry_package"
+	EventRelease                  = "release"
+	EventRepositoryDispatch       = "repository_dispatch"
+	EventSchedule                 = "schedule"
+	EventStatus                   = "status"
+	EventWatch                    = "watch"
+	EventWorkflowCall             = "workflow_call"
+	EventWorkflowDispatch         = "workflow_dispatch"
+	EventWorkflowRun              = "workflow_run"
+)
+
+// On represents a 'on' field in a GitHub Actions workflow parsed from YAML. All three forms are supported: a single
+// event name, a list of event names, and a mapping of event names to their configuration.
 type On struct {
-	WorkflowCall     *Call     `yaml:"workflow_call"`
-	WorkflowDispatch *Dispatch `yaml:"workflow_dispatch"`
+	Events           map[string]*Event
+	Schedule         []*Schedule
+	WorkflowCall     *Call
+	WorkflowDispatch *Dispatch
+}
+
+// Event represents filters of an event that triggers a workflow. Only the filters supported by a specific event
+// are set.
+type Event struct {
+	Types          []string
+	Branches       []string
+	BranchesIgnore []string
+	Tags           []string
+	TagsIgnore     []string
+	Paths          []string
+	PathsIgnore    []string
+	Workflows      []string
+}
+
+// Schedule represents an entry in the 'schedule' event.
+type Schedule struct {
+	Cron string `yaml:"cron"`
+}
+
+// UnmarshalYAML parses the 'on' field from any of its syntactic forms.
+func (o *On) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var raw interface{}
+
+	err := unmarshal(&raw)
+	if err != nil {
+		return err
+	}
+
+	o.Events = map[string]*Event{}
+
+	switch value := raw.(type) {
+	case string:
+		o.addEvent(value, nil)
+	case []interface{}:
+		for _, name := range value {
+			o.addEvent(yamlvalue.ToString(name), nil)
+		}
+	default:
+		events := yamlvalue.ToStringMap(value)
+		if events == nil {
+			return fmt.Errorf("%w, got %T", errOnInvalidType, value)
+		}
+
+		typed := struct {
+			Schedule         []*Schedule `yaml:"schedule"`
+			WorkflowCall     *Call       `yaml:"workflow_call"`
+			WorkflowDispatch *Dispatch   `yaml:"workflow_dispatch"`
+		}{}
+
+		err = unmarshal(&typed)
+		if err != nil {
+			return err
+		}
+
+		o.Schedule = typed.Schedule
+		o.WorkflowCall = typed.WorkflowCall
+		o.WorkflowDispatch = typed.WorkflowDispatch
+
+		for name, filters := range events {
+			o.addEvent(name, filters)
+		}
+	}
+
+	return nil
+}
+
+// GetEventNames returns sorted names of all the events that trigger the workflow.
+func (o *On) GetEventNames() []string {
+	if o == nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(o.Events))
+	for name := range o.Events {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// HasEvent checks whether the workflow is triggered by an event.
+func (o *On) HasEvent(name string) bool {
+	return o.GetEvent(name) != nil
+}
+
+// HasAnyEvent checks whether the workflow is triggered by at least one of the events.
+func (o *On) HasAnyEvent(names ...string) bool {
+	for _, name := range names {
+		if o.HasEvent(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetEvent returns filters of an event, or nil when the workflow is not triggered by it.
+func (o *On) GetEvent(name string) *Event {
+	if o == nil {
+		return nil
+	}
+
+	return o.Events[name]
+}
+
+func (o *On) addEvent(name string, filters interface{}) {
+	fields := yamlvalue.ToStringMap(filters)
+
+	o.Events[name] = &Event{
+		Types:          yamlvalue.ToStringSlice(fields["types"]),
+		Branches:       yamlvalue.ToStringSlice(fields["branches"]),
+		BranchesIgnore: yamlvalue.ToStringSlice(fields["branches-ignore"]),
+		Tags:           yamlvalue.ToStringSlice(fields["tags"]),
+		TagsIgnore:     yamlvalue.ToStringSlice(fields["tags-ignore"]),
+		Paths:          yamlvalue.ToStringSlice(fields["paths"]),
+		PathsIgnore:    yamlvalue.ToStringSlice(fields["paths-ignore"]),
+		Workflows:      yamlvalue.ToStringSlice(fields["workflows"]),
+	}
+
+	// keep the typed events set when they are listed without any configuration, eg. 'on: workflow_dispatch'
+	switch name {
+	case EventWorkflowCall:
+		if o.WorkflowCall == nil {
+			o.WorkflowCall = &Call{}
+		}
+	case EventWorkflowDispatch:
+		if o.WorkflowDispatch == nil {
+			o.WorkflowDispatch = &Dispatch{}
+		}
+	}
 }
@@ -0,0 +1,202 @@
+package workflow
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func unmarshalOn(t *testing.T, s string) (*On, error) {
+	t.Helper()
+
+	o := &On{}
+
+	err := yaml.Unmarshal([]byte(s), o)
+
+	return o, err
+}
+
+func TestOnUnmarshalYAML(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		yaml   string
+		events []string
+	}{
+		{yaml: "push", events: []string{EventPush}},
+		{yaml: "[push, pull_request]", events: []string{EventPullRequest, EventPush}},
+		{yaml: "- workflow_dispatch\n- push", events: []string{EventPush, EventWorkflowDispatch}},
+		{
+			yaml:   "push:\n  branches: [main]\npull_request_target:\nschedule:\n  - cron: '0 0 * * *'",
+			events: []string{EventPullRequestTarget, EventPush, EventSchedule},
+		},
+		{yaml: "{}", events: []string{}},
+	} {
+		o, err := unmarshalOn(t, tt.yaml)
+		if err != nil {
+			t.Errorf("On.UnmarshalYAML on '%s' failed with an error: %s", tt.yaml, err.Error())
+
+			continue
+		}
+
+		if !reflect.DeepEqual(o.GetEventNames(), tt.events) {
+			t.Errorf("On.GetEventNames on '%s' should return %v, got %v", tt.yaml, tt.events, o.GetEventNames())
+		}
+	}
+
+	_, err := unmarshalOn(t, "123")
+	if err == nil {
+		t.Errorf("On.UnmarshalYAML on a number should return an error")
+	}
+}
+
+func TestOnEventFilters(t *testing.T) {
+	t.Parallel()
+
+	o, err := unmarshalOn(t, `
+push:
+  branches: [main, 'release/**']
+  tags: v*
+  paths-ignore: [docs/**]
+pull_request:
+  types: [opened, synchronize]
+  branches-ignore: [wip/**]
+  paths: [src/**]
+workflow_run:
+  workflows: [CI, "Build and test"]
+  types: [completed]
+schedule:
+  - cron: '0 0 * * *'
+  - cron: '30 6 * * 1'
+`)
+	if err != nil {
+		t.Fatalf("On.UnmarshalYAML failed with an error: %s", err.Error())
+	}
+
+	for name, expected := range map[string]*Event{
+		EventPush: {
+			Branches:    []string{"main", "release/**"},
+			Tags:        []string{"v*"},
+			PathsIgnore: []string{"docs/**"},
+		},
+		EventPullRequest: {
+			Types:          []string{"opened", "synchronize"},
+			BranchesIgnore: []string{"wip/**"},
+			Paths:          []string{"src/**"},
+		},
+		EventWorkflowRun: {
+			Types:     []string{"completed"},
+			Workflows: []string{"CI", "Build and test"},
+		},
+		EventSchedule: {},
+	} {
+		if !reflect.DeepEqual(o.GetEvent(name), expected) {
+			t.Errorf("On.GetEvent(%s) should return %+v, got %+v", name, expected, o.GetEvent(name))
+		}
+	}
+
+	expectedSchedule := []*Schedule{{Cron: "0 0 * * *"}, {Cron: "30 6 * * 1"}}
+	if !reflect.DeepEqual(o.Schedule, expectedSchedule) {
+		t.Errorf("On.Schedule should be %+v, got %+v", expectedSchedule, o.Schedule)
+	}
+
+	if !o.HasEvent(EventWorkflowRun) || o.HasEvent(EventPullRequestTarget) {
+		t.Errorf("On.HasEvent should return true only for events that trigger the workflow")
+	}
+
+	if !o.HasAnyEvent(EventPullRequestTarget, EventPush) || o.HasAnyEvent(EventIssues, EventRelease) {
+		t.Errorf("On.HasAnyEvent should return true only when one of the events triggers the workflow")
+	}
+
+	if o.GetEvent(EventIssues) != nil {
+		t.Errorf("On.GetEvent should return nil for an event that does not trigger the workflow")
+	}
+}
+
+func TestOnTypedInputs(t *testing.T) {
+	t.Parallel()
+
+	o, err := unmarshalOn(t, `
+workflow_call:
+  inputs:
+    version:
+      type: string
+      required: true
+    dry-run:
+      type: boolean
+      default: false
+  secrets:
+    token:
+      required: true
+workflow_dispatch:
+  inputs:
+    environment:
+      type: choice
+      options: [staging, production]
+    message:
+      description: Message
+`)
+	if err != nil {
+		t.Fatalf("On.UnmarshalYAML failed with an error: %s", err.Error())
+	}
+
+	if o.WorkflowCall == nil || o.WorkflowDispatch == nil {
+		t.Fatalf("On should have 'workflow_call' and 'workflow_dispatch' set, got %+v", o)
+	}
+
+	for _, tt := range []struct {
+		input      *Input
+		inputType  string
+		isFreeText bool
+	}{
+		{input: o.WorkflowCall.Inputs["version"], inputType: InputTypeString, isFreeText: true},
+		{input: o.WorkflowCall.Inputs["dry-run"], inputType: "boolean", isFreeText: false},
+		{input: o.WorkflowDispatch.Inputs["environment"], inputType: InputTypeChoice, isFreeText: false},
+		{input: o.WorkflowDispatch.Inputs["message"], inputType: "", isFreeText: true},
+	} {
+		if tt.input == nil {
+			t.Errorf("input of type '%s' should be set", tt.inputType)
+
+			continue
+		}
+
+		if tt.input.Type != tt.inputType || tt.input.IsFreeText() != tt.isFreeText {
+			t.Errorf(
+				"Input should have type '%s' and IsFreeText %v, got %+v",
+				tt.inputType,
+				tt.isFreeText,
+				tt.input,
+			)
+		}
+	}
+
+	if !o.WorkflowCall.Inputs["version"].Required || o.WorkflowCall.Secrets["token"] == nil {
+		t.Errorf("'workflow_call' should have the required input and the secret, got %+v", o.WorkflowCall)
+	}
+
+	if !reflect.DeepEqual(o.WorkflowDispatch.Inputs["environment"].Options, []string{"staging", "production"}) {
+		t.Errorf("'workflow_dispatch' input should have options, got %+v", o.WorkflowDispatch.Inputs["environment"])
+	}
+
+	for _, s := range []string{"workflow_dispatch", "[workflow_call]", "workflow_dispatch:\n"} {
+		o, err = unmarshalOn(t, s)
+		if err != nil {
+			t.Fatalf("On.UnmarshalYAML on '%s' failed with an error: %s", s, err.Error())
+		}
+
+		if o.WorkflowCall == nil && o.WorkflowDispatch == nil {
+			t.Errorf("On.UnmarshalYAML on '%s' should set the typed event without configuration", s)
+		}
+	}
+}
+
+func TestOnNil(t *testing.T) {
+	t.Parallel()
+
+	var o *On
+
+	if o.HasEvent(EventPush) || o.GetEvent(EventPush) != nil || o.GetEventNames() != nil {
+		t.Errorf("On methods should handle nil")
+	}
+}
@@ -0,0 +1,77 @@
+package workflow
+
+import "octo-linter/internal/yamlvalue"
+
+const (
+	// PermissionsReadAll is a shorthand granting read access to all the scopes.
+	PermissionsReadAll = "read-all"
+	// PermissionsWriteAll is a shorthand granting write access to all the scopes.
+	PermissionsWriteAll = "write-all"
+	// PermissionRead is a scope access level allowing reading.
+	PermissionRead = "read"
+	// PermissionWrite is a scope access level allowing reading and writing.
+	PermissionWrite = "write"
+	// PermissionNone is a scope access level denying any access.
+	PermissionNone = "none"
+)
+
+// Permissions represents a 'permissions' field of a workflow or a job. It is either a shorthand, such as 'read-all',
+// or a mapping of a scope to its access level.
+type Permissions struct {
+	Shorthand string
+	Scopes    map[string]string
+}
+
+// IsReadAll checks whether the 'read-all' shorthand is used.
+func (p *Permissions) IsReadAll() bool {
+	return p.Shorthand == PermissionsReadAll
+}
+
+// IsWriteAll checks whether the 'write-all' shorthand is used.
+func (p *Permissions) IsWriteAll() bool {
+	return p.Shorthand == PermissionsWriteAll
+}
+
+// GetScope returns access level of a scope, eg. 'contents'. Scopes that are not listed get 'none', unless
+// a shorthand is used.
+func (p *Permissions) GetScope(name string) string {
+	switch p.Shorthand {
+	case PermissionsReadAll:
+		return PermissionRead
+	case PermissionsWriteAll:
+		return PermissionWrite
+	}
+
+	level, ok := p.Scopes[name]
+	if !ok {
+		return PermissionNone
+	}
+
+	return level
+}
+
+func newPermissions(value interface{}) *Permissions {
+	if value == nil {
+		return nil
+	}
+
+	shorthand, isString := value.(string)
+	if isString {
+		return &Permissions{Shorthand: shorthand}
+	}
+
+	scopes := yamlvalue.ToStringMap(value)
+	if scopes == nil {
+		return nil
+	}
+
+	permissions := &Permissions{
+		Scopes: make(map[string]string, len(scopes)),
+	}
+
+	for scope, level := range scopes {
+		permissions.Scopes[scope] = yamlvalue.ToString(level)
+	}
+
+	return permissions
+}
@@ -0,0 +1,72 @@
+package workflow
+
+import (
+	"testing"
+)
+
+func TestGetPermissions(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		yaml       string
+		isNil      bool
+		isReadAll  bool
+		isWriteAll bool
+		scopes     map[string]string
+	}{
+		{yaml: "runs-on: ubuntu-latest", isNil: true},
+		{
+			yaml:      "permissions: read-all",
+			isReadAll: true,
+			scopes:    map[string]string{"contents": PermissionRead, "issues": PermissionRead},
+		},
+		{
+			yaml:       "permissions: write-all",
+			isWriteAll: true,
+			scopes:     map[string]string{"contents": PermissionWrite},
+		},
+		{
+			yaml:   "permissions: {}",
+			scopes: map[string]string{"contents": PermissionNone},
+		},
+		{
+			yaml: "permissions:\n  contents: read\n  pull-requests: write\n  id-token: none",
+			scopes: map[string]string{
+				"contents":      PermissionRead,
+				"pull-requests": PermissionWrite,
+				"id-token":      PermissionNone,
+				"issues":        PermissionNone,
+			},
+		},
+		{yaml: "permissions: [contents]", isNil: true},
+	} {
+		job := unmarshalJob(t, tt.yaml)
+
+		permissions := job.GetPermissions()
+		if (permissions == nil) != tt.isNil {
+			t.Errorf("Job.GetPermissions on '%s' should return nil: %v, got %+v", tt.yaml, tt.isNil, permissions)
+
+			continue
+		}
+
+		if permissions == nil {
+			continue
+		}
+
+		if permissions.IsReadAll() != tt.isReadAll || permissions.IsWriteAll() != tt.isWriteAll {
+			t.Errorf("Permissions on '%s' should have read-all %v and write-all %v", tt.yaml, tt.isReadAll, tt.isWriteAll)
+		}
+
+		for scope, expected := range tt.scopes {
+			if permissions.GetScope(scope) != expected {
+				t.Errorf(
+					"Permissions.GetScope(%s) on '%s' should return %s, got %s",
+					scope,
+					tt.yaml,
+					expected,
+					permissions.GetScope(scope),
+				)
+			}
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package workflow
+
+import "octo-linter/internal/yamlvalue"
+
+// RunsOn represents a 'runs-on' field of a job. It is either a label, a list of labels, or a mapping with a runner
+// group and labels.
+type RunsOn struct {
+	Group  string
+	Labels []string
+}
+
+func newRunsOn(value interface{}) *RunsOn {
+	if value == nil {
+		return nil
+	}
+
+	fields := yamlvalue.ToStringMap(value)
+	if fields == nil {
+		return &RunsOn{Labels: yamlvalue.ToStringSlice(value)}
+	}
+
+	return &RunsOn{
+		Group:  yamlvalue.ToString(fields["group"]),
+		Labels: yamlvalue.ToStringSlice(fields["labels"]),
+	}
+}
@@ -0,0 +1,86 @@
+package workflow
+
+import "octo-linter/internal/yamlvalue"
+
+// Strategy represents a 'strategy' field of a job.
+type Strategy struct {
+	Matrix      interface{} `yaml:"matrix"`
+	FailFast    interface{} `yaml:"fail-fast"`
+	MaxParallel interface{} `yaml:"max-parallel"`
+}
+
+// Matrix represents a 'strategy.matrix' field of a job. When the whole matrix is an expression, eg.
+// '${{ fromJSON(needs.setup.outputs.matrix) }}', only Expression is set.
+type Matrix struct {
+	Expression string
+	Dimensions map[string][]interface{}
+	Include    []map[string]interface{}
+	Exclude    []map[string]interface{}
+}
+
+// GetMatrix returns the parsed 'matrix' field, or nil when it is not defined.
+func (s *Strategy) GetMatrix() *Matrix {
+	if s.Matrix == nil {
+		return nil
+	}
+
+	expression, isString := s.Matrix.(string)
+	if isString {
+		return &Matrix{Expression: expression}
+	}
+
+	fields := yamlvalue.ToStringMap(s.Matrix)
+	if fields == nil {
+		return nil
+	}
+
+	matrix := &Matrix{
+		Dimensions: map[string][]interface{}{},
+	}
+
+	for name, value := range fields {
+		switch name {
+		case "include":
+			matrix.Include = toMapSlice(value)
+		case "exclude":
+			matrix.Exclude = toMapSlice(value)
+		default:
+			values, isList := value.([]interface{})
+			if !isList {
+				values = []interface{}{value}
+			}
+
+			matrix.Dimensions[name] = values
+		}
+	}
+
+	return matrix
+}
+
+// GetFailFast returns the 'fail-fast' value. The second value is false when it is not set or it is an expression.
+func (s *Strategy) GetFailFast() (bool, bool) {
+	return yamlvalue.ToBool(s.FailFast)
+}
+
+// GetMaxParallel returns the 'max-parallel' value. The second value is false when it is not set or it is an
+// expression.
+func (s *Strategy) GetMaxParallel() (int, bool) {
+	return yamlvalue.ToInt(s.MaxParallel)
+}
+
+func toMapSlice(value interface{}) []map[string]interface{} {
+	list, isList := value.([]interface{})
+	if !isList {
+		return nil
+	}
+
+	maps := make([]map[string]interface{}, 0, len(list))
+	for _, item := range list {
+		itemMap := yamlvalue.ToStringMap(item)
+		if itemMap != nil {
+			maps = append(maps, itemMap)
+		}
+	}
+
+	return maps
+}
@@ -0,0 +1,88 @@
+package workflow
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetMatrix(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		yaml     string
+		expected *Matrix
+	}{
+		{yaml: "strategy:\n  fail-fast: false", expected: nil},
+		{
+			yaml:     "strategy:\n  matrix: ${{ fromJSON(needs.setup.outputs.matrix) }}",
+			expected: &Matrix{Expression: "${{ fromJSON(needs.setup.outputs.matrix) }}"},
+		},
+		{
+			yaml: "strategy:\n  matrix:\n    os: [ubuntu-latest, windows-latest]\n    node: 20\n" +
+				"    include:\n      - os: macos-latest\n        node: 22\n    exclude:\n      - os: windows-latest",
+			expected: &Matrix{
+				Dimensions: map[string][]interface{}{
+					"os":   {"ubuntu-latest", "windows-latest"},
+					"node": {20},
+				},
+				Include: []map[string]interface{}{{"os": "macos-latest", "node": 22}},
+				Exclude: []map[string]interface{}{{"os": "windows-latest"}},
+			},
+		},
+		{
+			yaml: "strategy:\n  matrix:\n    version: ${{ fromJSON(inputs.versions) }}",
+			expected: &Matrix{
+				Dimensions: map[string][]interface{}{"version": {"${{ fromJSON(inputs.versions) }}"}},
+			},
+		},
+		{yaml: "strategy:\n  matrix: [a]", expected: nil},
+	} {
+		job := unmarshalJob(t, tt.yaml)
+
+		result := job.Strategy.GetMatrix()
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Errorf("Strategy.GetMatrix on '%s' should return %+v, got %+v", tt.yaml, tt.expected, result)
+		}
+	}
+}
+
+func TestGetFailFastAndMaxParallel(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		yaml          string
+		failFast      bool
+		failFastOk    bool
+		maxParallel   int
+		maxParallelOk bool
+	}{
+		{yaml: "strategy:\n  matrix:\n    os: [a]"},
+		{
+			yaml:          "strategy:\n  fail-fast: false\n  max-parallel: 2",
+			failFast:      false,
+			failFastOk:    true,
+			maxParallel:   2,
+			maxParallelOk: true,
+		},
+		{yaml: "strategy:\n  fail-fast: ${{ inputs.fail-fast }}\n  max-parallel: ${{ inputs.max }}"},
+	} {
+		strategy := unmarshalJob(t, tt.yaml).Strategy
+
+		failFast, ok := strategy.GetFailFast()
+		if failFast != tt.failFast || ok != tt.failFastOk {
+			t.Errorf("Strategy.GetFailFast on '%s' should return %v, %v, got %v, %v", tt.yaml, tt.failFast, tt.failFastOk, failFast, ok)
+		}
+
+		maxParallel, ok := strategy.GetMaxParallel()
+		if maxParallel != tt.maxParallel || ok != tt.maxParallelOk {
+			t.Errorf(
+				"Strategy.GetMaxParallel on '%s' should return %v, %v, got %v, %v",
+				tt.yaml,
+				tt.maxParallel,
+				tt.maxParallelOk,
+				maxParallel,
+				ok,
+			)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package workflow
+
+import (
+	"strings"
+)
+
+// IsExpression checks whether the value is a GitHub Actions expression, eg. '${{ inputs.timeout }}', which can only
+// be resolved at runtime.
+func IsExpression(s string) bool {
+	s = strings.TrimSpace(s)
+
+	return strings.HasPrefix(s, "${{") && strings.HasSuffix(s, "}}")
+}
@@ -0,0 +1,62 @@
+package workflow
+
+import "testing"
+
+func TestIsExpression(t *testing.T) {
+	t.Parallel()
+
+	for value, expected := range map[string]bool{
+		"${{ matrix.os }}":       true,
+		"  ${{ inputs.x }}  ":    true,
+		"ubuntu-${{ matrix.v }}": false,
+		"ubuntu-latest":          false,
+		"":                       false,
+	} {
+		if IsExpression(value) != expected {
+			t.Errorf("IsExpression(%s) should return %v", value, expected)
+		}
+	}
+}
+
+func TestWorkflowGetPermissionsAndConcurrency(t *testing.T) {
+	t.Parallel()
+
+	w := &Workflow{
+		Path: "main.yml",
+		Raw:  []byte("permissions: read-all\nconcurrency: ${{ github.workflow }}\njobs: {}\n"),
+	}
+
+	err := w.Unmarshal()
+	if err != nil {
+		t.Fatalf("Workflow.Unmarshal failed with an error: %s", err.Error())
+	}
+
+	if w.GetPermissions() == nil || !w.GetPermissions().IsReadAll() {
+		t.Errorf("Workflow.GetPermissions should return read-all, got %+v", w.GetPermissions())
+	}
+
+	if w.GetConcurrency() == nil || w.GetConcurrency().Group != "${{ github.workflow }}" {
+		t.Errorf("Workflow.GetConcurrency should return the group, got %+v", w.GetConcurrency())
+	}
+}
+
+func TestWorkflowGetJobNames(t *testing.T) {
+	t.Parallel()
+
+	w := &Workflow{
+		Path: "main.yml",
+		Raw:  []byte("jobs:\n  zeta:\n    runs-on: ubuntu-latest\n  alpha:\n    runs-on: ubuntu-latest\n"),
+	}
+
+	err := w.Unmarshal()
+	if err != nil {
+		t.Fatalf("Workflow.Unmarshal failed with an error: %s", err.Error())
+	}
+
+	w.Jobs["beta"] = &Job{}
+
+	jobNames := w.GetJobNames()
+	if len(jobNames) != 3 || jobNames[0] != "zeta" || jobNames[1] != "alpha" || jobNames[2] != "beta" {
+		t.Errorf("Workflow.GetJobNames should return jobs in file order followed by the rest, got %v", jobNames)
+	}
+}
@@ -3,8 +3,8 @@ package workflow
 
 import (
 	"fmt"
-	"log/slog"
-	"os"
+	"maps"
+	"slices"
 	"strings"
 
 	"gopkg.in/yaml.v2"
@@ -22,32 +22,26 @@ type Workflow struct {
 	FileName    string
 	DisplayName string
 	Name        string            `yaml:"name"`
+	RunName     string            `yaml:"run-name"`
 	Description string            `yaml:"description"`
 	Env         map[string]string `yaml:"env"`
 	Jobs        map[string]*Job   `yaml:"jobs"`
 	On          *On               `yaml:"on"`
+	Permissions interface{}       `yaml:"permissions"`
+	Concurrency interface{}       `yaml:"concurrency"`
+	Defaults    *Defaults         `yaml:"defaults"`
+	// jobNames contains names of jobs in the order they appear in Raw.
+	jobNames []string
 }
 
-// Unmarshal parses YAML from a file in struct's Path or from struct's Raw field.
-func (w *Workflow) Unmarshal(_ bool) error {
+// Unmarshal parses YAML from struct's Raw field.
+func (w *Workflow) Unmarshal() error {
 	pathSplit := strings.Split(w.Path, "/")
 	w.FileName = pathSplit[len(pathSplit)-1]
 	workflowName := strings.ReplaceAll(w.FileName, ".yaml", "")
 	w.DisplayName = strings.ReplaceAll(workflowName, ".yml", "")
 
-	slog.Debug(
-		"reading workflow file",
-		slog.String("path", w.Path),
-	)
-
-	b, err := os.ReadFile(w.Path)
-	if err != nil {
-		return fmt.Errorf("cannot read file %s: %w", w.Path, err)
-	}
-
-	w.Raw = b
-
-	err = yaml.Unmarshal(w.Raw, &w)
+	err := yaml.Unmarshal(w.Raw, &w)
 	if err != nil {
 		return fmt.Errorf("cannot unmarshal file %s: %w", w.Path, err)
 	}
@@ -58,10 +52,55 @@ func (w *Workflow) Unmarshal(_ bool) error {
 		}
 	}
 
+	var jobsInOrder struct {
+		Jobs yaml.MapSlice `yaml:"jobs"`
+	}
+
+	err = yaml.Unmarshal(w.Raw, &jobsInOrder)
+	if err != nil {
+		return fmt.Errorf("cannot unmarshal jobs of file %s: %w", w.Path, err)
+	}
+
+	w.jobNames = make([]string, 0, len(jobsInOrder.Jobs))
+	for _, item := range jobsInOrder.Jobs {
+		w.jobNames = append(w.jobNames, fmt.Sprint(item.Key))
+	}
+
 	return nil
 }
 
+// GetJobNames returns names of jobs in the order they appear in the file, so that positions of identical texts in
+// different jobs can be looked up one after another. Jobs that are not in the file, eg. when the workflow was not
+// unmarshaled, follow in alphabetical order.
+func (w *Workflow) GetJobNames() []string {
+	jobNames := []string{}
+
+	for _, jobName := range w.jobNames {
+		if _, ok := w.Jobs[jobName]; ok && !slices.Contains(jobNames, jobName) {
+			jobNames = append(jobNames, jobName)
+		}
+	}
+
+	for _, jobName := range slices.Sorted(maps.Keys(w.Jobs)) {
+		if !slices.Contains(jobNames, jobName) {
+			jobNames = append(jobNames, jobName)
+		}
+	}
+
+	return jobNames
+}
+
 // GetType returns the int value representing the workflow file type. See dotgithub.File interface.
 func (w *Workflow) GetType() int {
 	return DotGithubFileTypeWorkflow
 }
+
+// GetPermissions returns the parsed top-level 'permissions' field, or nil when it is not defined.
+func (w *Workflow) GetPermissions() *Permissions {
+	return newPermissions(w.Permissions)
+}
+
+// GetConcurrency returns the parsed top-level 'concurrency' field, or nil when it is not defined.
+func (w *Workflow) GetConcurrency() *Concurrency {
+	return newConcurrency(w.Concurrency)
+}
@@ -0,0 +1,90 @@
+// Package yamlvalue contains functions converting YAML values decoded into interface{}, eg. fields that can be
+// a scalar, a list or a mapping, to Go types.
+package yamlvalue
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ToStringMap converts a YAML mapping decoded into interface{} to a map with string keys. It returns nil when the
+// value is not a mapping.
+func ToStringMap(value interface{}) map[string]interface{} {
+	switch mapValue := value.(type) {
+	case map[interface{}]interface{}:
+		stringMap := make(map[string]interface{}, len(mapValue))
+		for k, v := range mapValue {
+			stringMap[fmt.Sprint(k)] = v
+		}
+
+		return stringMap
+	case map[string]interface{}:
+		return mapValue
+	default:
+		return nil
+	}
+}
+
+// ToStringSlice converts a YAML scalar or a sequence of scalars decoded into interface{} to a slice of strings.
+func ToStringSlice(value interface{}) []string {
+	switch sliceValue := value.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		strs := make([]string, 0, len(sliceValue))
+		for _, v := range sliceValue {
+			strs = append(strs, ToString(v))
+		}
+
+		return strs
+	case []string:
+		return sliceValue
+	default:
+		return []string{ToString(sliceValue)}
+	}
+}
+
+// ToString converts a YAML scalar decoded into interface{} to string. It returns an empty string for nil.
+func ToString(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+
+	return fmt.Sprint(value)
+}
+
+// ToBool converts a YAML scalar to bool. The second value is false when the value is not set or it is an expression.
+func ToBool(value interface{}) (bool, bool) {
+	switch boolValue := value.(type) {
+	case bool:
+		return boolValue, true
+	case string:
+		parsed, err := strconv.ParseBool(boolValue)
+		if err != nil {
+			return false, false
+		}
+
+		return parsed, true
+	default:
+		return false, false
+	}
+}
+
+// ToInt converts a YAML scalar to int. The second value is false when the value is not set or it is an expression.
+func ToInt(value interface{}) (int, bool) {
+	switch intValue := value.(type) {
+	case int:
+		return intValue, true
+	case float64:
+		return int(intValue), true
+	case string:
+		parsed, err := strconv.Atoi(intValue)
+		if err != nil {
+			return 0, false
+		}
+
+		return parsed, true
+	default:
+		return 0, false
+	}
+}
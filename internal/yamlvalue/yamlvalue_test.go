@@ -0,0 +1,111 @@
+package yamlvalue
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToStringMap(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		value    interface{}
+		expected map[string]interface{}
+	}{
+		{value: map[interface{}]interface{}{"a": 1, 2: "b"}, expected: map[string]interface{}{"a": 1, "2": "b"}},
+		{value: map[string]interface{}{"a": 1}, expected: map[string]interface{}{"a": 1}},
+		{value: "a", expected: nil},
+		{value: []interface{}{"a"}, expected: nil},
+		{value: nil, expected: nil},
+	} {
+		result := ToStringMap(tt.value)
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Errorf("ToStringMap(%#v) should return %#v, got %#v", tt.value, tt.expected, result)
+		}
+	}
+}
+
+func TestToStringSlice(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		value    interface{}
+		expected []string
+	}{
+		{value: nil, expected: nil},
+		{value: "ubuntu-latest", expected: []string{"ubuntu-latest"}},
+		{value: "${{ matrix.os }}", expected: []string{"${{ matrix.os }}"}},
+		{value: 4, expected: []string{"4"}},
+		{value: []interface{}{"a", 1, true}, expected: []string{"a", "1", "true"}},
+		{value: []string{"a"}, expected: []string{"a"}},
+	} {
+		result := ToStringSlice(tt.value)
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Errorf("ToStringSlice(%#v) should return %#v, got %#v", tt.value, tt.expected, result)
+		}
+	}
+}
+
+func TestToString(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		value    interface{}
+		expected string
+	}{
+		{value: nil, expected: ""},
+		{value: "a", expected: "a"},
+		{value: true, expected: "true"},
+		{value: 1.5, expected: "1.5"},
+		{value: 10, expected: "10"},
+	} {
+		result := ToString(tt.value)
+		if result != tt.expected {
+			t.Errorf("ToString(%#v) should return %s, got %s", tt.value, tt.expected, result)
+		}
+	}
+}
+
+func TestToBool(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		value      interface{}
+		expected   bool
+		expectedOk bool
+	}{
+		{value: true, expected: true, expectedOk: true},
+		{value: false, expected: false, expectedOk: true},
+		{value: "true", expected: true, expectedOk: true},
+		{value: "${{ inputs.allow-failure }}", expected: false, expectedOk: false},
+		{value: nil, expected: false, expectedOk: false},
+		{value: 1, expected: false, expectedOk: false},
+	} {
+		result, ok := ToBool(tt.value)
+		if result != tt.expected || ok != tt.expectedOk {
+			t.Errorf("ToBool(%#v) should return %v, %v, got %v, %v", tt.value, tt.expected, tt.expectedOk, result, ok)
+		}
+	}
+}
+
+func TestToInt(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		value      interface{}
+		expected   int
+		expectedOk bool
+	}{
+		{value: 10, expected: 10, expectedOk: true},
+		{value: 10.0, expected: 10, expectedOk: true},
+		{value: "10", expected: 10, expectedOk: true},
+		{value: "${{ inputs.timeout }}", expected: 0, expectedOk: false},
+		{value: nil, expected: 0, expectedOk: false},
+		{value: true, expected: 0, expectedOk: false},
+	} {
+		result, ok := ToInt(tt.value)
+		if result != tt.expected || ok != tt.expectedOk {
+			t.Errorf("ToInt(%#v) should return %v, %v, got %v, %v", tt.value, tt.expected, tt.expectedOk, result, ok)
+		}
+	}
+}